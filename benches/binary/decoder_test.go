@@ -0,0 +1,80 @@
+package binary_test
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/itzmeanjan/kodr/binary"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+)
+
+func BenchmarkBinaryRLNCDecoder(t *testing.B) {
+	t.Run("1M", func(b *testing.B) {
+		b.Run("16 Pieces", func(b *testing.B) { decode(b, 1<<4, 1<<20) })
+		b.Run("32 Pieces", func(b *testing.B) { decode(b, 1<<5, 1<<20) })
+		b.Run("64 Pieces", func(b *testing.B) { decode(b, 1<<6, 1<<20) })
+		b.Run("128 Pieces", func(b *testing.B) { decode(b, 1<<7, 1<<20) })
+		b.Run("256 Pieces", func(b *testing.B) { decode(b, 1<<8, 1<<20) })
+	})
+
+	t.Run("16M", func(b *testing.B) {
+		b.Run("16 Pieces", func(b *testing.B) { decode(b, 1<<4, 1<<24) })
+		b.Run("32 Pieces", func(b *testing.B) { decode(b, 1<<5, 1<<24) })
+		b.Run("64 Pieces", func(b *testing.B) { decode(b, 1<<6, 1<<24) })
+		b.Run("128 Pieces", func(b *testing.B) { decode(b, 1<<7, 1<<24) })
+		b.Run("256 Pieces", func(b *testing.B) { decode(b, 1<<8, 1<<24) })
+	})
+
+	t.Run("32M", func(b *testing.B) {
+		b.Run("16 Pieces", func(b *testing.B) { decode(b, 1<<4, 1<<25) })
+		b.Run("32 Pieces", func(b *testing.B) { decode(b, 1<<5, 1<<25) })
+		b.Run("64 Pieces", func(b *testing.B) { decode(b, 1<<6, 1<<25) })
+		b.Run("128 Pieces", func(b *testing.B) { decode(b, 1<<7, 1<<25) })
+		b.Run("256 Pieces", func(b *testing.B) { decode(b, 1<<8, 1<<25) })
+	})
+}
+
+// Along with decoding time, reports how many coded pieces beyond N
+// had to be received on average, because over GF(2) chance of
+// receiving linearly dependent pieces is much higher than GF(2^8)
+func decode(t *testing.B, pieceCount uint, total uint) {
+	data := generateRandomData(total)
+
+	enc, err := binary.NewBinaryRLNCEncoderWithPieceCount(data, pieceCount)
+	if err != nil {
+		t.Fatalf("Error: %s\n", err.Error())
+	}
+
+	pieces := make([]*kodr_internals.CodedPiece, 0, 2*pieceCount)
+	for range 2 * pieceCount {
+		pieces = append(pieces, enc.CodedPiece())
+	}
+
+	t.ResetTimer()
+
+	totalDuration := 0 * time.Second
+	totalOverhead := uint(0)
+	for t.Loop() {
+		dec := binary.NewBinaryRLNCDecoder(pieceCount)
+
+		// Random shuffle piece ordering
+		rand.Shuffle(len(pieces), func(i, j int) {
+			pieces[i], pieces[j] = pieces[j], pieces[i]
+		})
+
+		begin := time.Now()
+		for j := 0; !dec.IsDecoded(); j++ {
+			if j == len(pieces) {
+				pieces = append(pieces, enc.CodedPiece())
+			}
+			dec.AddPiece(pieces[j])
+		}
+		totalDuration += time.Since(begin)
+		totalOverhead += dec.Received() - pieceCount
+	}
+
+	t.ReportMetric(0, "ns/op")
+	t.ReportMetric(float64(totalDuration.Seconds())/float64(t.N), "second/decode")
+	t.ReportMetric(float64(totalOverhead)/float64(t.N), "extra-pieces/decode")
+}
@@ -0,0 +1,85 @@
+package erasure_test
+
+import (
+	"crypto/rand"
+	math_rand "math/rand"
+	"testing"
+	"time"
+
+	"github.com/itzmeanjan/kodr/erasure"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+)
+
+func BenchmarkErasureDecoder(t *testing.B) {
+	t.Run("1M", func(b *testing.B) {
+		b.Run("16 Pieces", func(b *testing.B) { decode(b, 1<<4, 1<<20) })
+		b.Run("32 Pieces", func(b *testing.B) { decode(b, 1<<5, 1<<20) })
+		b.Run("64 Pieces", func(b *testing.B) { decode(b, 1<<6, 1<<20) })
+		b.Run("128 Pieces", func(b *testing.B) { decode(b, 1<<7, 1<<20) })
+	})
+
+	t.Run("16M", func(b *testing.B) {
+		b.Run("16 Pieces", func(b *testing.B) { decode(b, 1<<4, 1<<24) })
+		b.Run("32 Pieces", func(b *testing.B) { decode(b, 1<<5, 1<<24) })
+		b.Run("64 Pieces", func(b *testing.B) { decode(b, 1<<6, 1<<24) })
+		b.Run("128 Pieces", func(b *testing.B) { decode(b, 1<<7, 1<<24) })
+	})
+}
+
+func generateRandomData(n uint) []byte {
+	data := make([]byte, n)
+	rand.Read(data)
+
+	return data
+}
+
+// Half of repair pieces are used for recovering as many
+// lost original pieces, while erasure pattern stays same
+// across iterations, so that cached inverse is reused
+func decode(t *testing.B, pieceCount uint, total uint) {
+	repairCount := pieceCount / 2
+	gen, err := erasure.NewSystematicGenerator(pieceCount, repairCount)
+	if err != nil {
+		t.Fatalf("Error: %s\n", err.Error())
+	}
+
+	enc, err := erasure.NewErasureEncoderWithData(generateRandomData(total), gen)
+	if err != nil {
+		t.Fatalf("Error: %s\n", err.Error())
+	}
+
+	order := math_rand.Perm(int(pieceCount + repairCount))
+	pieces := make([]kodr_internals.Piece, len(order))
+	for i := range order {
+		c_piece, err := enc.CodedPiece(uint(order[i]))
+		if err != nil {
+			t.Fatalf("Error: %s\n", err.Error())
+		}
+		pieces[i] = c_piece.Piece
+	}
+
+	dec := erasure.NewErasureDecoder(gen)
+
+	t.ResetTimer()
+
+	totalDuration := 0 * time.Second
+	for t.Loop() {
+		dec.Reset()
+
+		begin := time.Now()
+		for i := range order {
+			if dec.IsDecoded() {
+				break
+			}
+			dec.AddPiece(uint(order[i]), pieces[i])
+		}
+		totalDuration += time.Since(begin)
+
+		if !dec.IsDecoded() {
+			t.Fatal("expected pieces to be already decoded")
+		}
+	}
+
+	t.ReportMetric(0, "ns/op")
+	t.ReportMetric(float64(totalDuration.Seconds())/float64(t.N), "second/decode")
+}
@@ -0,0 +1,73 @@
+package fountain_test
+
+import (
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/itzmeanjan/kodr/fountain"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+)
+
+func generateRandomData(n uint) []byte {
+	data := make([]byte, n)
+	rand.Read(data)
+
+	return data
+}
+
+func BenchmarkLTDecoder(t *testing.B) {
+	t.Run("16M", func(b *testing.B) {
+		b.Run("1024 Pieces", func(b *testing.B) { decode(b, 1<<10, 1<<24) })
+		b.Run("4096 Pieces", func(b *testing.B) { decode(b, 1<<12, 1<<24) })
+		b.Run("16384 Pieces", func(b *testing.B) { decode(b, 1<<14, 1<<24) })
+	})
+
+	t.Run("32M", func(b *testing.B) {
+		b.Run("1024 Pieces", func(b *testing.B) { decode(b, 1<<10, 1<<25) })
+		b.Run("4096 Pieces", func(b *testing.B) { decode(b, 1<<12, 1<<25) })
+		b.Run("16384 Pieces", func(b *testing.B) { decode(b, 1<<14, 1<<25) })
+	})
+}
+
+// Along with decoding time, reports how many coded pieces beyond N
+// had to be received & how many pieces were decoded by Gaussian
+// elimination, on average
+func decode(t *testing.B, pieceCount uint, total uint) {
+	enc, err := fountain.NewLTEncoderWithPieceCount(generateRandomData(total), pieceCount, 0.03, 0.5)
+	if err != nil {
+		t.Fatalf("Error: %s\n", err.Error())
+	}
+
+	pieces := make([]*kodr_internals.CodedPiece, 0, 2*pieceCount)
+	for range 2 * pieceCount {
+		pieces = append(pieces, enc.CodedPiece())
+	}
+
+	t.ResetTimer()
+
+	totalDuration := 0 * time.Second
+	totalOverhead := uint(0)
+	totalEliminated := uint(0)
+	for t.Loop() {
+		dec := fountain.NewLTDecoder(pieceCount)
+
+		begin := time.Now()
+		for j := 0; !dec.IsDecoded(); j++ {
+			if j == len(pieces) {
+				pieces = append(pieces, enc.CodedPiece())
+			}
+			dec.AddPiece(pieces[j])
+		}
+		totalDuration += time.Since(begin)
+		totalOverhead += dec.Received() - pieceCount
+
+		_, eliminated := dec.Stats()
+		totalEliminated += eliminated
+	}
+
+	t.ReportMetric(0, "ns/op")
+	t.ReportMetric(float64(totalDuration.Seconds())/float64(t.N), "second/decode")
+	t.ReportMetric(float64(totalOverhead)/float64(t.N), "extra-pieces/decode")
+	t.ReportMetric(float64(totalEliminated)/float64(t.N), "eliminated/decode")
+}
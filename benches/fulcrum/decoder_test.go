@@ -0,0 +1,95 @@
+package fulcrum_test
+
+import (
+	"crypto/rand"
+	math_rand "math/rand"
+	"testing"
+	"time"
+
+	"github.com/itzmeanjan/kodr/fulcrum"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+)
+
+func generateRandomData(n uint) []byte {
+	data := make([]byte, n)
+	rand.Read(data)
+
+	return data
+}
+
+type decoder interface {
+	IsDecoded() bool
+	Received() uint
+	AddPiece(*kodr_internals.CodedPiece) error
+}
+
+func BenchmarkFulcrumDecoder(t *testing.B) {
+	outer := func(pieceCount, expansionCount uint) decoder {
+		dec, _ := fulcrum.NewFulcrumOuterDecoder(pieceCount, expansionCount)
+		return dec
+	}
+	inner := func(pieceCount, expansionCount uint) decoder {
+		return fulcrum.NewFulcrumInnerDecoder(pieceCount, expansionCount)
+	}
+	combined := func(pieceCount, expansionCount uint) decoder {
+		dec, _ := fulcrum.NewFulcrumCombinedDecoder(pieceCount, expansionCount)
+		return dec
+	}
+
+	for _, total := range []struct {
+		name string
+		size uint
+	}{{"1M", 1 << 20}, {"16M", 1 << 24}} {
+		t.Run(total.name, func(b *testing.B) {
+			b.Run("Outer/64 Pieces", func(b *testing.B) { decode(b, outer, 1<<6, 8, total.size) })
+			b.Run("Inner/64 Pieces", func(b *testing.B) { decode(b, inner, 1<<6, 8, total.size) })
+			b.Run("Combined/64 Pieces", func(b *testing.B) { decode(b, combined, 1<<6, 8, total.size) })
+			b.Run("Outer/128 Pieces", func(b *testing.B) { decode(b, outer, 1<<7, 8, total.size) })
+			b.Run("Inner/128 Pieces", func(b *testing.B) { decode(b, inner, 1<<7, 8, total.size) })
+			b.Run("Combined/128 Pieces", func(b *testing.B) { decode(b, combined, 1<<7, 8, total.size) })
+		})
+	}
+}
+
+// Along with decoding time, reports how many coded pieces beyond N
+// had to be received on average, by each kind of decoder
+func decode(t *testing.B, newDecoder func(uint, uint) decoder, pieceCount, expansionCount uint, total uint) {
+	data := generateRandomData(total)
+
+	enc, err := fulcrum.NewFulcrumEncoderWithPieceCount(data, pieceCount, expansionCount)
+	if err != nil {
+		t.Fatalf("Error: %s\n", err.Error())
+	}
+
+	pieces := make([]*kodr_internals.CodedPiece, 0, 2*pieceCount)
+	for range 2 * pieceCount {
+		pieces = append(pieces, enc.CodedPiece())
+	}
+
+	t.ResetTimer()
+
+	totalDuration := 0 * time.Second
+	totalOverhead := uint(0)
+	for t.Loop() {
+		dec := newDecoder(pieceCount, expansionCount)
+
+		// Random shuffle piece ordering
+		math_rand.Shuffle(len(pieces), func(i, j int) {
+			pieces[i], pieces[j] = pieces[j], pieces[i]
+		})
+
+		begin := time.Now()
+		for j := 0; !dec.IsDecoded(); j++ {
+			if j == len(pieces) {
+				pieces = append(pieces, enc.CodedPiece())
+			}
+			dec.AddPiece(pieces[j])
+		}
+		totalDuration += time.Since(begin)
+		totalOverhead += dec.Received() - pieceCount
+	}
+
+	t.ReportMetric(0, "ns/op")
+	t.ReportMetric(float64(totalDuration.Seconds())/float64(t.N), "second/decode")
+	t.ReportMetric(float64(totalOverhead)/float64(t.N), "extra-pieces/decode")
+}
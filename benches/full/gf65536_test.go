@@ -0,0 +1,91 @@
+package full_test
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/itzmeanjan/kodr/full"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+)
+
+func BenchmarkFullRLNCEncoderGf65536(t *testing.B) {
+	t.Run("1M", func(b *testing.B) {
+		b.Run("16 Pieces", func(b *testing.B) { encodeGf65536(b, 1<<4, 1<<20) })
+		b.Run("64 Pieces", func(b *testing.B) { encodeGf65536(b, 1<<6, 1<<20) })
+		b.Run("256 Pieces", func(b *testing.B) { encodeGf65536(b, 1<<8, 1<<20) })
+		b.Run("1024 Pieces", func(b *testing.B) { encodeGf65536(b, 1<<10, 1<<20) })
+	})
+
+	t.Run("16M", func(b *testing.B) {
+		b.Run("16 Pieces", func(b *testing.B) { encodeGf65536(b, 1<<4, 1<<24) })
+		b.Run("64 Pieces", func(b *testing.B) { encodeGf65536(b, 1<<6, 1<<24) })
+		b.Run("256 Pieces", func(b *testing.B) { encodeGf65536(b, 1<<8, 1<<24) })
+		b.Run("1024 Pieces", func(b *testing.B) { encodeGf65536(b, 1<<10, 1<<24) })
+	})
+}
+
+func BenchmarkFullRLNCDecoderGf65536(t *testing.B) {
+	t.Run("1M", func(b *testing.B) {
+		b.Run("16 Pieces", func(b *testing.B) { decodeGf65536(b, 1<<4, 1<<20) })
+		b.Run("64 Pieces", func(b *testing.B) { decodeGf65536(b, 1<<6, 1<<20) })
+		b.Run("256 Pieces", func(b *testing.B) { decodeGf65536(b, 1<<8, 1<<20) })
+		b.Run("1024 Pieces", func(b *testing.B) { decodeGf65536(b, 1<<10, 1<<20) })
+	})
+}
+
+func encodeGf65536(t *testing.B, pieceCount uint, total uint) {
+	data := generateRandomData(total)
+
+	enc, err := full.NewFullRLNCEncoderGf65536WithPieceCount(data, pieceCount)
+	if err != nil {
+		t.Fatalf("Error: %s\n", err.Error())
+	}
+
+	t.ReportAllocs()
+	t.SetBytes(int64(total+enc.Padding()) + int64(enc.CodedPieceLen()))
+	t.ResetTimer()
+
+	for t.Loop() {
+		enc.CodedPiece()
+	}
+}
+
+func decodeGf65536(t *testing.B, pieceCount uint, total uint) {
+	data := generateRandomData(total)
+
+	enc, err := full.NewFullRLNCEncoderGf65536WithPieceCount(data, pieceCount)
+	if err != nil {
+		t.Fatalf("Error: %s\n", err.Error())
+	}
+
+	pieces := make([]*kodr_internals.CodedPiece, 0, pieceCount+2)
+	for range pieceCount + 2 {
+		pieces = append(pieces, enc.CodedPiece())
+	}
+
+	t.ResetTimer()
+
+	totalDuration := 0 * time.Second
+	for t.Loop() {
+		dec := full.NewFullRLNCDecoderGf65536(pieceCount)
+
+		// Random shuffle piece ordering
+		rand.Shuffle(len(pieces), func(i, j int) {
+			pieces[i], pieces[j] = pieces[j], pieces[i]
+		})
+
+		begin := time.Now()
+		for j := 0; j < len(pieces) && !dec.IsDecoded(); j++ {
+			dec.AddPiece(pieces[j])
+		}
+		totalDuration += time.Since(begin)
+
+		if !dec.IsDecoded() {
+			t.Fatal("expected pieces to be already decoded")
+		}
+	}
+
+	t.ReportMetric(0, "ns/op")
+	t.ReportMetric(float64(totalDuration.Seconds())/float64(t.N), "second/decode")
+}
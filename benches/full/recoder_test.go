@@ -57,3 +57,83 @@ func recode(t *testing.B, pieceCount uint, total uint) {
 		rec.CodedPiece()
 	}
 }
+
+// Relay receives 4N pieces, each being sent 4 times, which blind recoder
+// combines all of, while incremental one recodes only its basis
+func BenchmarkIncrementalFullRLNCRecoder(t *testing.B) {
+	t.Run("1M", func(b *testing.B) {
+		b.Run("64 Pieces/Blind", func(b *testing.B) { recodeReceived(b, 1<<6, 1<<20, false) })
+		b.Run("64 Pieces/Incremental", func(b *testing.B) { recodeReceived(b, 1<<6, 1<<20, true) })
+		b.Run("128 Pieces/Blind", func(b *testing.B) { recodeReceived(b, 1<<7, 1<<20, false) })
+		b.Run("128 Pieces/Incremental", func(b *testing.B) { recodeReceived(b, 1<<7, 1<<20, true) })
+	})
+}
+
+func recodeReceived(t *testing.B, pieceCount uint, total uint, incremental bool) {
+	data := generateRandomData(total)
+	enc, err := full.NewFullRLNCEncoderWithPieceCount(data, pieceCount)
+	if err != nil {
+		t.Fatalf("Error: %s\n", err.Error())
+	}
+
+	received := make([]*kodr_internals.CodedPiece, 0, 4*pieceCount)
+	for range pieceCount {
+		c_piece := enc.CodedPiece()
+		for range 4 {
+			received = append(received, c_piece)
+		}
+	}
+
+	var recode func() (*kodr_internals.CodedPiece, error)
+	if incremental {
+		rec := full.NewIncrementalFullRLNCRecoder(pieceCount)
+		for _, c_piece := range received {
+			rec.AddPiece(c_piece)
+		}
+		recode = rec.CodedPiece
+	} else {
+		recode = full.NewFullRLNCRecoder(received).CodedPiece
+	}
+
+	t.ReportAllocs()
+	t.SetBytes(int64(pieceCount + total/pieceCount))
+	t.ResetTimer()
+
+	for t.Loop() {
+		recode()
+	}
+}
+
+// Recoding with limited fan-in combines only k held pieces, so that its
+// cost stays same, as #-of held pieces grows
+func BenchmarkSparseFullRLNCRecoder(t *testing.B) {
+	t.Run("1M", func(b *testing.B) {
+		b.Run("64 Pieces/Fan-in 4", func(b *testing.B) { recodeSparse(b, 1<<6, 1<<20, 4) })
+		b.Run("128 Pieces/Fan-in 4", func(b *testing.B) { recodeSparse(b, 1<<7, 1<<20, 4) })
+		b.Run("256 Pieces/Fan-in 4", func(b *testing.B) { recodeSparse(b, 1<<8, 1<<20, 4) })
+		b.Run("256 Pieces/Fan-in 16", func(b *testing.B) { recodeSparse(b, 1<<8, 1<<20, 16) })
+	})
+}
+
+func recodeSparse(t *testing.B, pieceCount uint, total uint, fanIn uint) {
+	data := generateRandomData(total)
+	enc, err := full.NewFullRLNCEncoderWithPieceCount(data, pieceCount)
+	if err != nil {
+		t.Fatalf("Error: %s\n", err.Error())
+	}
+
+	pieces := make([]*kodr_internals.CodedPiece, 0, pieceCount)
+	for range pieceCount {
+		pieces = append(pieces, enc.CodedPiece())
+	}
+
+	rec := full.NewFullRLNCRecoder(pieces)
+
+	t.ReportAllocs()
+	t.SetBytes(int64(pieceCount + total/pieceCount))
+	t.ResetTimer()
+
+	for t.Loop() {
+		rec.SparseCodedPiece(fanIn)
+	}
+}
@@ -0,0 +1,95 @@
+package full_test
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/itzmeanjan/kodr/full"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/matrix"
+)
+
+// Decodes same sparse coded pieces ( ~8 nonzero coefficients each ),
+// using dense & sparse ( inactivation ) decoder states
+func BenchmarkInactivation(t *testing.B) {
+	t.Run("1M", func(b *testing.B) {
+		b.Run("256 Pieces/Dense", func(b *testing.B) { decodeSparse(b, 1<<8, 1<<20, false) })
+		b.Run("256 Pieces/Sparse", func(b *testing.B) { decodeSparse(b, 1<<8, 1<<20, true) })
+		b.Run("1024 Pieces/Dense", func(b *testing.B) { decodeSparse(b, 1<<10, 1<<20, false) })
+		b.Run("1024 Pieces/Sparse", func(b *testing.B) { decodeSparse(b, 1<<10, 1<<20, true) })
+	})
+
+	t.Run("16M", func(b *testing.B) {
+		b.Run("256 Pieces/Dense", func(b *testing.B) { decodeSparse(b, 1<<8, 1<<24, false) })
+		b.Run("256 Pieces/Sparse", func(b *testing.B) { decodeSparse(b, 1<<8, 1<<24, true) })
+		b.Run("1024 Pieces/Dense", func(b *testing.B) { decodeSparse(b, 1<<10, 1<<24, false) })
+		b.Run("1024 Pieces/Sparse", func(b *testing.B) { decodeSparse(b, 1<<10, 1<<24, true) })
+	})
+}
+
+// Along with decoding time, reports how many coded pieces beyond N
+// had to be received & how many columns sparse decoder state had to
+// inactivate i.e. solve densely, on average
+func decodeSparse(t *testing.B, pieceCount uint, total uint, sparse bool) {
+	pieces, _, err := kodr_internals.OriginalPiecesFromDataAndPieceCount(generateRandomData(total), pieceCount)
+	if err != nil {
+		t.Fatalf("Error: %s\n", err.Error())
+	}
+	enc := full.NewSparseFullRLNCEncoder(full.NewFullRLNCEncoder(pieces), full.FixedDensity(8/float64(pieceCount)))
+
+	c_pieces := make([]*kodr_internals.CodedPiece, 0, 2*pieceCount)
+	for range 2 * pieceCount {
+		c_pieces = append(c_pieces, enc.CodedPiece())
+	}
+
+	t.ResetTimer()
+
+	totalDuration := 0 * time.Second
+	totalOverhead := uint(0)
+	totalInactivated := uint(0)
+	for t.Loop() {
+		var (
+			dec   *full.FullRLNCDecoder
+			state *matrix.SparseDecoderState
+		)
+		if sparse {
+			state = matrix.NewSparseDecoderStateWithPieceCount(pieceCount)
+			dec = full.NewFullRLNCDecoderWithState(pieceCount, state)
+		} else {
+			dec = full.NewFullRLNCDecoder(pieceCount)
+		}
+
+		// Random shuffle piece ordering
+		rand.Shuffle(len(c_pieces), func(i, j int) {
+			c_pieces[i], c_pieces[j] = c_pieces[j], c_pieces[i]
+		})
+
+		received := uint(0)
+		begin := time.Now()
+		for j := 0; !dec.IsDecoded(); j++ {
+			if j == len(c_pieces) {
+				c_pieces = append(c_pieces, enc.CodedPiece())
+			}
+
+			// dense decoder state works in-place, so it gets a copy
+			c_piece := &kodr_internals.CodedPiece{
+				Vector: append(kodr_internals.CodingVector(nil), c_pieces[j].Vector...),
+				Piece:  append(kodr_internals.Piece(nil), c_pieces[j].Piece...),
+			}
+			dec.AddPiece(c_piece)
+			received++
+		}
+		totalDuration += time.Since(begin)
+		totalOverhead += received - pieceCount
+
+		if sparse {
+			totalInactivated += state.Inactivated()
+		}
+	}
+
+	t.ReportMetric(0, "ns/op")
+	t.ReportMetric(float64(totalDuration.Seconds())/float64(t.N), "second/decode")
+	t.ReportMetric(float64(totalOverhead)/float64(t.N), "extra-pieces/decode")
+	t.ReportMetric(float64(totalInactivated)/float64(t.N), "inactivated/decode")
+}
@@ -0,0 +1,95 @@
+package perpetual_test
+
+import (
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/itzmeanjan/kodr/full"
+	"github.com/itzmeanjan/kodr/perpetual"
+)
+
+func generateRandomData(n uint) []byte {
+	data := make([]byte, n)
+	rand.Read(data)
+
+	return data
+}
+
+func BenchmarkPerpetualDecoder(t *testing.B) {
+	t.Run("1M", func(b *testing.B) {
+		b.Run("128 Pieces/Width 16", func(b *testing.B) { decode(b, 1<<7, 16, 1<<20) })
+		b.Run("128 Pieces/Width 32", func(b *testing.B) { decode(b, 1<<7, 32, 1<<20) })
+		b.Run("256 Pieces/Width 16", func(b *testing.B) { decode(b, 1<<8, 16, 1<<20) })
+		b.Run("256 Pieces/Width 32", func(b *testing.B) { decode(b, 1<<8, 32, 1<<20) })
+		b.Run("256 Pieces/Full RLNC", func(b *testing.B) { decodeDense(b, 1<<8, 32, 1<<20) })
+	})
+
+	t.Run("16M", func(b *testing.B) {
+		b.Run("128 Pieces/Width 16", func(b *testing.B) { decode(b, 1<<7, 16, 1<<24) })
+		b.Run("128 Pieces/Width 32", func(b *testing.B) { decode(b, 1<<7, 32, 1<<24) })
+		b.Run("256 Pieces/Width 16", func(b *testing.B) { decode(b, 1<<8, 16, 1<<24) })
+		b.Run("256 Pieces/Width 32", func(b *testing.B) { decode(b, 1<<8, 32, 1<<24) })
+		b.Run("256 Pieces/Full RLNC", func(b *testing.B) { decodeDense(b, 1<<8, 32, 1<<24) })
+	})
+}
+
+// Along with decoding time, reports how many coded pieces beyond N
+// had to be received on average
+func decode(t *testing.B, pieceCount, width uint, total uint) {
+	enc, err := perpetual.NewPerpetualEncoderWithPieceCount(generateRandomData(total), pieceCount, width, perpetual.RandomOffset)
+	if err != nil {
+		t.Fatalf("Error: %s\n", err.Error())
+	}
+
+	pieces := make([]*perpetual.CodedPiece, 0, 2*pieceCount)
+	for range 2 * pieceCount {
+		pieces = append(pieces, enc.CodedPiece())
+	}
+
+	t.ResetTimer()
+
+	totalDuration := 0 * time.Second
+	totalOverhead := uint(0)
+	for t.Loop() {
+		dec, _ := perpetual.NewPerpetualDecoder(pieceCount, width)
+
+		begin := time.Now()
+		for j := 0; !dec.IsDecoded(); j++ {
+			if j == len(pieces) {
+				pieces = append(pieces, enc.CodedPiece())
+			}
+			dec.AddPiece(pieces[j])
+		}
+		totalDuration += time.Since(begin)
+		totalOverhead += dec.Received() - pieceCount
+	}
+
+	t.ReportMetric(0, "ns/op")
+	t.ReportMetric(float64(totalDuration.Seconds())/float64(t.N), "second/decode")
+	t.ReportMetric(float64(totalOverhead)/float64(t.N), "extra-pieces/decode")
+}
+
+// Decodes same band coded pieces using full RLNC decoder, for comparison
+func decodeDense(t *testing.B, pieceCount, width uint, total uint) {
+	enc, err := perpetual.NewPerpetualEncoderWithPieceCount(generateRandomData(total), pieceCount, width, perpetual.RandomOffset)
+	if err != nil {
+		t.Fatalf("Error: %s\n", err.Error())
+	}
+
+	t.ResetTimer()
+
+	totalDuration := 0 * time.Second
+	for t.Loop() {
+		dec := full.NewFullRLNCDecoder(pieceCount)
+
+		begin := time.Now()
+		for !dec.IsDecoded() {
+			dec.AddPiece(enc.CodedPiece().Dense(pieceCount))
+		}
+		totalDuration += time.Since(begin)
+	}
+
+	t.ReportMetric(0, "ns/op")
+	t.ReportMetric(float64(totalDuration.Seconds())/float64(t.N), "second/decode")
+}
@@ -0,0 +1,91 @@
+package precode_test
+
+import (
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/itzmeanjan/kodr/fountain"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/precode"
+)
+
+func generateRandomData(n uint) []byte {
+	data := make([]byte, n)
+	rand.Read(data)
+
+	return data
+}
+
+func BenchmarkPrecodeDecoder(t *testing.B) {
+	t.Run("16M", func(b *testing.B) {
+		b.Run("1024 Pieces", func(b *testing.B) { decode(b, 1<<10, 1<<24) })
+		b.Run("4096 Pieces", func(b *testing.B) { decode(b, 1<<12, 1<<24) })
+		b.Run("16384 Pieces", func(b *testing.B) { decode(b, 1<<14, 1<<24) })
+	})
+
+	t.Run("32M", func(b *testing.B) {
+		b.Run("1024 Pieces", func(b *testing.B) { decode(b, 1<<10, 1<<25) })
+		b.Run("4096 Pieces", func(b *testing.B) { decode(b, 1<<12, 1<<25) })
+		b.Run("16384 Pieces", func(b *testing.B) { decode(b, 1<<14, 1<<25) })
+	})
+}
+
+// LT encoder sits on top of LDPC precoded pieces, where ~2% parity pieces
+// are added. Along with decoding time, reports how many coded pieces beyond
+// N ( source pieces ) had to be received & how many intermediate pieces
+// were decoded by Gaussian elimination, on average
+func decode(t *testing.B, pieceCount uint, total uint) {
+	pieces, _, err := kodr_internals.OriginalPiecesFromDataAndPieceCount(generateRandomData(total), pieceCount)
+	if err != nil {
+		t.Fatalf("Error: %s\n", err.Error())
+	}
+
+	pre, err := precode.NewLDPCPrecode(pieceCount, pieceCount/50+1)
+	if err != nil {
+		t.Fatalf("Error: %s\n", err.Error())
+	}
+
+	precoded, err := pre.Encode(pieces)
+	if err != nil {
+		t.Fatalf("Error: %s\n", err.Error())
+	}
+
+	dist, err := fountain.NewRobustSoliton(pre.PieceCount(), 0.03, 0.5)
+	if err != nil {
+		t.Fatalf("Error: %s\n", err.Error())
+	}
+	enc := fountain.NewLTEncoder(precoded, dist)
+
+	c_pieces := make([]*kodr_internals.CodedPiece, 0, 2*pieceCount)
+	for range 2 * pieceCount {
+		c_pieces = append(c_pieces, enc.CodedPiece())
+	}
+
+	t.ResetTimer()
+
+	totalDuration := 0 * time.Second
+	totalOverhead := uint(0)
+	totalEliminated := uint(0)
+	for t.Loop() {
+		dec := precode.NewPrecodeDecoderWithFallbackOverhead(pre, 0.05)
+
+		begin := time.Now()
+		for j := 0; !dec.IsDecoded(); j++ {
+			if j == len(c_pieces) {
+				c_pieces = append(c_pieces, enc.CodedPiece())
+			}
+			dec.AddPiece(c_pieces[j])
+		}
+		totalDuration += time.Since(begin)
+		totalOverhead += dec.Received() - pieceCount
+
+		_, eliminated := dec.Stats()
+		totalEliminated += eliminated
+	}
+
+	t.ReportMetric(0, "ns/op")
+	t.ReportMetric(float64(totalDuration.Seconds())/float64(t.N), "second/decode")
+	t.ReportMetric(float64(totalOverhead)/float64(t.N), "extra-pieces/decode")
+	t.ReportMetric(float64(totalEliminated)/float64(t.N), "eliminated/decode")
+}
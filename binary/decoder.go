@@ -0,0 +1,88 @@
+package binary
+
+import (
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf2"
+)
+
+type BinaryRLNCDecoder struct {
+	expected, useful, received uint
+	state                      *gf2.DecoderState
+}
+
+// PieceLength - Returns piece length in bytes
+//
+// If no pieces are yet added to decoder state, then
+// returns 0, denoting **unknown**
+func (d *BinaryRLNCDecoder) PieceLength() uint {
+	return d.state.PieceLength()
+}
+
+// IsDecoded - Use it for checking whether more piece
+// collection is required or not
+func (d *BinaryRLNCDecoder) IsDecoded() bool {
+	return d.useful >= d.expected
+}
+
+// Required - How many more linearly independent pieces
+// are required for successfully decoding pieces ?
+func (d *BinaryRLNCDecoder) Required() uint {
+	return d.expected - d.useful
+}
+
+// Received - How many coded pieces are received so far, including
+// linearly dependent ones, which helps in measuring overhead
+// of coding over GF(2)
+func (d *BinaryRLNCDecoder) Received() uint {
+	return d.received
+}
+
+// AddPiece - Adds a new received coded piece, which is eliminated
+// right away using already received pieces, by XOR-ing 64-bit words
+// of bit-packed coding vectors along with coded pieces
+func (d *BinaryRLNCDecoder) AddPiece(piece *kodr_internals.CodedPiece) error {
+	if d.IsDecoded() {
+		return kodr.ErrAllUsefulPiecesReceived
+	}
+
+	if _, err := d.state.AddPiece(piece.Vector, piece.Piece); err != nil {
+		return err
+	}
+	d.received++
+	d.useful = d.state.Rank()
+	return nil
+}
+
+// GetPiece - Get a decoded piece by index, may ( not ) succeed !
+//
+// Note: It's not necessary that full decoding needs to happen
+// for this method to return something useful
+func (d *BinaryRLNCDecoder) GetPiece(i uint) (kodr_internals.Piece, error) {
+	return d.state.GetPiece(i)
+}
+
+// GetPieces - Get a list of all decoded pieces, given full
+// decoding has happened
+func (d *BinaryRLNCDecoder) GetPieces() ([]kodr_internals.Piece, error) {
+	if !d.IsDecoded() {
+		return nil, kodr.ErrMoreUsefulPiecesRequired
+	}
+
+	pieces := make([]kodr_internals.Piece, 0, d.expected)
+	for i := range d.expected {
+		piece, err := d.GetPiece(i)
+		if err != nil {
+			return nil, err
+		}
+		pieces = append(pieces, piece)
+	}
+	return pieces, nil
+}
+
+// Decoder for pieces coded ( or recoded ) over GF(2), by
+// any of binary, binary systematic encoders or binary recoder
+func NewBinaryRLNCDecoder(pieceCount uint) *BinaryRLNCDecoder {
+	state := gf2.NewDecoderState(pieceCount)
+	return &BinaryRLNCDecoder{expected: pieceCount, state: state}
+}
@@ -0,0 +1,50 @@
+package binary_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/binary"
+)
+
+func TestNewBinaryRLNCDecoder(t *testing.T) {
+	var (
+		pieceCount  uint = 64
+		pieceLength uint = 1024
+		pieces           = generatePieces(pieceCount, pieceLength)
+		enc              = binary.NewBinaryRLNCEncoder(pieces)
+		dec              = binary.NewBinaryRLNCDecoder(pieceCount)
+	)
+
+	if dec.PieceLength() != 0 {
+		t.Fatal("expected piece length to be unknown")
+	}
+
+	needed := pieceCount
+	for !dec.IsDecoded() {
+		if _, err := dec.GetPieces(); !(err != nil && errors.Is(err, kodr.ErrMoreUsefulPiecesRequired)) {
+			t.Fatal("expected error indicating more pieces are required for decoding")
+		}
+
+		if err := dec.AddPiece(enc.CodedPiece()); err != nil {
+			t.Fatal(err.Error())
+		}
+
+		if req := dec.Required(); req > needed {
+			t.Fatal("expected required piece count to monotonically decrease")
+		} else {
+			needed = req
+		}
+	}
+
+	if dec.PieceLength() != pieceLength {
+		t.Fatalf("expected piece length %d, found %d\n", pieceLength, dec.PieceLength())
+	}
+	if dec.Received() < pieceCount {
+		t.Fatalf("expected at least %d pieces to be received, found %d\n", pieceCount, dec.Received())
+	}
+	if err := dec.AddPiece(enc.CodedPiece()); !(err != nil && errors.Is(err, kodr.ErrAllUsefulPiecesReceived)) {
+		t.Fatal("expected error indication, received nothing !")
+	}
+}
@@ -0,0 +1,106 @@
+package binary
+
+import (
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf2"
+)
+
+type BinaryRLNCEncoder struct {
+	pieces []kodr_internals.Piece
+	extra  uint
+}
+
+// Total #-of pieces being coded together --- denoting
+// these many linearly independent pieces are required
+// successfully decoding back to original pieces
+func (b *BinaryRLNCEncoder) PieceCount() uint {
+	return uint(len(b.pieces))
+}
+
+// Pieces which are coded together are all of same size
+//
+// Total data being coded = pieceSize * pieceCount ( may include
+// some padding bytes )
+func (b *BinaryRLNCEncoder) PieceSize() uint {
+	return uint(len(b.pieces[0]))
+}
+
+// How many bytes of data, constructed by concatenating
+// coded pieces together, required at minimum for decoding
+// back to original pieces ?
+//
+// Note: Over GF(2), more than N coded pieces are generally
+// required, because chance of receiving linearly dependent
+// pieces is much higher
+func (b *BinaryRLNCEncoder) DecodableLen() uint {
+	return b.PieceCount() * b.CodedPieceLen()
+}
+
+// If N-many original pieces are coded together
+// what could be length of one such coded piece
+// obtained by invoking `CodedPiece` ?
+//
+// Coding vector is bit-packed, so it takes ceil(N/8) bytes
+func (b *BinaryRLNCEncoder) CodedPieceLen() uint {
+	return gf2.VectorLen(b.PieceCount()) + b.PieceSize()
+}
+
+// How many extra padding bytes added at end of
+// original data slice so that splitted pieces are
+// all of same size ?
+func (b *BinaryRLNCEncoder) Padding() uint {
+	return b.extra
+}
+
+// Returns a coded piece, which is constructed on-the-fly
+// by randomly selecting a subset of original pieces
+// & XOR-ing them together
+func (b *BinaryRLNCEncoder) CodedPiece() *kodr_internals.CodedPiece {
+	vector := gf2.Random(b.PieceCount())
+	piece := make(kodr_internals.Piece, b.PieceSize())
+	for i := range b.pieces {
+		if gf2.Get(vector, uint(i)) {
+			gf2.Xor(piece, b.pieces[i])
+		}
+	}
+	return &kodr_internals.CodedPiece{
+		Vector: vector,
+		Piece:  piece,
+	}
+}
+
+// Provide with original pieces on which binary RLNC to be performed
+// & get encoder, to be used for on-the-fly generation
+// to N-many coded pieces
+func NewBinaryRLNCEncoder(pieces []kodr_internals.Piece) *BinaryRLNCEncoder {
+	return &BinaryRLNCEncoder{pieces: pieces}
+}
+
+// If you know #-of pieces you want to code together, invoking
+// this function splits whole data chunk into N-pieces, with padding
+// bytes appended at end of last piece, if required & prepares
+// binary RLNC encoder for obtaining coded pieces
+func NewBinaryRLNCEncoderWithPieceCount(data []byte, pieceCount uint) (*BinaryRLNCEncoder, error) {
+	pieces, padding, err := kodr_internals.OriginalPiecesFromDataAndPieceCount(data, pieceCount)
+	if err != nil {
+		return nil, err
+	}
+
+	enc := NewBinaryRLNCEncoder(pieces)
+	enc.extra = padding
+	return enc, nil
+}
+
+// If you want to have N-bytes piece size for each, this
+// function generates M-many pieces each of N-bytes size, which are ready
+// to be coded together with binary RLNC
+func NewBinaryRLNCEncoderWithPieceSize(data []byte, pieceSize uint) (*BinaryRLNCEncoder, error) {
+	pieces, padding, err := kodr_internals.OriginalPiecesFromDataAndPieceSize(data, pieceSize)
+	if err != nil {
+		return nil, err
+	}
+
+	enc := NewBinaryRLNCEncoder(pieces)
+	enc.extra = padding
+	return enc, nil
+}
@@ -0,0 +1,140 @@
+package binary_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"math"
+	math_rand "math/rand"
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/binary"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+)
+
+// Generates `N`-bytes of random data from default
+// randomization source
+func generateData(n uint) []byte {
+	data := make([]byte, n)
+	rand.Read(data)
+	return data
+}
+
+// Generates N-many pieces each of M-bytes length, to be used
+// for testing purposes
+func generatePieces(pieceCount uint, pieceLength uint) []kodr_internals.Piece {
+	pieces := make([]kodr_internals.Piece, 0, pieceCount)
+	for range pieceCount {
+		pieces = append(pieces, generateData(pieceLength))
+	}
+	return pieces
+}
+
+// Keeps feeding coded pieces to decoder until it's able to decode,
+// then compares decoded pieces with original ones
+func decoderFlow(t *testing.T, next func() *kodr_internals.CodedPiece, pieceCount uint, pieces []kodr_internals.Piece) {
+	dec := binary.NewBinaryRLNCDecoder(pieceCount)
+	for {
+		if err := dec.AddPiece(next()); errors.Is(err, kodr.ErrAllUsefulPiecesReceived) {
+			break
+		}
+	}
+
+	d_pieces, err := dec.GetPieces()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if len(pieces) != len(d_pieces) {
+		t.Fatal("didn't decode all !")
+	}
+
+	for i := range pieces {
+		if !bytes.Equal(pieces[i], d_pieces[i]) {
+			t.Fatal("decoded data doesn't match !")
+		}
+	}
+}
+
+func TestNewBinaryRLNCEncoder(t *testing.T) {
+	var (
+		pieceCount  uint = 128
+		pieceLength uint = 8192
+		pieces           = generatePieces(pieceCount, pieceLength)
+		enc              = binary.NewBinaryRLNCEncoder(pieces)
+	)
+
+	if enc.CodedPieceLen() != pieceCount/8+pieceLength {
+		t.Fatalf("expected coded piece length %dB, found %dB\n", pieceCount/8+pieceLength, enc.CodedPieceLen())
+	}
+
+	decoderFlow(t, enc.CodedPiece, pieceCount, pieces)
+}
+
+func TestNewBinaryRLNCEncoderWithPieceCount(t *testing.T) {
+	size := uint(2<<10 + math_rand.Intn(2<<10))
+	pieceCount := uint(2<<1 + math_rand.Intn(2<<8))
+	data := generateData(size)
+
+	pieces, _, err := kodr_internals.OriginalPiecesFromDataAndPieceCount(data, pieceCount)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	enc, err := binary.NewBinaryRLNCEncoderWithPieceCount(data, pieceCount)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	c_piece := enc.CodedPiece()
+	if c_piece.Len() != enc.CodedPieceLen() {
+		t.Fatalf("expected coded piece to be of %dB, found to be of %dB\n", enc.CodedPieceLen(), c_piece.Len())
+	}
+
+	decoderFlow(t, enc.CodedPiece, pieceCount, pieces)
+}
+
+func TestNewBinaryRLNCEncoderWithPieceSize(t *testing.T) {
+	size := uint(2<<10 + math_rand.Intn(2<<10))
+	pieceSize := uint(2<<5 + math_rand.Intn(2<<5))
+	pieceCount := uint(math.Ceil(float64(size) / float64(pieceSize)))
+	data := generateData(size)
+
+	pieces, _, err := kodr_internals.OriginalPiecesFromDataAndPieceSize(data, pieceSize)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	enc, err := binary.NewBinaryRLNCEncoderWithPieceSize(data, pieceSize)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if extra := enc.Padding(); (size+extra)/pieceCount != pieceSize {
+		t.Fatalf("expected pieceSize to be %dB, found to be %dB\n", pieceSize, (size+extra)/pieceCount)
+	}
+
+	decoderFlow(t, enc.CodedPiece, pieceCount, pieces)
+}
+
+func TestNewBinarySystematicRLNCEncoder(t *testing.T) {
+	var (
+		pieceCount  uint = 128
+		pieceLength uint = 8192
+		pieces           = generatePieces(pieceCount, pieceLength)
+		enc              = binary.NewBinarySystematicRLNCEncoder(pieces)
+	)
+
+	// simulate random coded piece loss
+	next := func() *kodr_internals.CodedPiece {
+		for {
+			c_piece := enc.CodedPiece()
+			if math_rand.Intn(2) == 0 {
+				return c_piece
+			}
+		}
+	}
+
+	decoderFlow(t, next, pieceCount, pieces)
+}
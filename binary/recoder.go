@@ -0,0 +1,54 @@
+package binary
+
+import (
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf2"
+)
+
+type BinaryRLNCRecoder struct {
+	pieces []*kodr_internals.CodedPiece
+}
+
+// Returns recoded piece, which is constructed on-the-fly
+// by randomly selecting a subset of coded pieces & XOR-ing
+// both their coding vectors & pieces together
+//
+// No matrix multiplication is required, because over GF(2)
+// recoded coding vector is just XOR of selected coding vectors
+func (r *BinaryRLNCRecoder) CodedPiece() *kodr_internals.CodedPiece {
+	selected := gf2.Random(uint(len(r.pieces)))
+	vector := make(kodr_internals.CodingVector, len(r.pieces[0].Vector))
+	piece := make(kodr_internals.Piece, len(r.pieces[0].Piece))
+
+	for i := range r.pieces {
+		if !gf2.Get(selected, uint(i)) {
+			continue
+		}
+
+		gf2.Xor(vector, r.pieces[i].Vector)
+		gf2.Xor(piece, r.pieces[i].Piece)
+	}
+
+	return &kodr_internals.CodedPiece{
+		Vector: vector,
+		Piece:  piece,
+	}
+}
+
+// Provide with all coded pieces, which are to be used
+// for performing binary RLNC recoding & get back recoder
+func NewBinaryRLNCRecoder(pieces []*kodr_internals.CodedPiece) *BinaryRLNCRecoder {
+	return &BinaryRLNCRecoder{pieces: pieces}
+}
+
+// A byte slice which is formed by concatenating coded pieces,
+// will be splitted into structured coded pieces, where coding vector
+// of each is ceil(piecesCodedTogether/8) bytes
+func NewBinaryRLNCRecoderWithFlattenData(data []byte, pieceCount uint, piecesCodedTogether uint) (*BinaryRLNCRecoder, error) {
+	codedPieces, err := kodr_internals.CodedPiecesForRecoding(data, pieceCount, gf2.VectorLen(piecesCodedTogether))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewBinaryRLNCRecoder(codedPieces), nil
+}
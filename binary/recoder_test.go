@@ -0,0 +1,48 @@
+package binary_test
+
+import (
+	"testing"
+
+	"github.com/itzmeanjan/kodr/binary"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+)
+
+func TestNewBinaryRLNCRecoder(t *testing.T) {
+	var (
+		pieceCount      uint = 128
+		pieceLength     uint = 8192
+		codedPieceCount      = pieceCount + 16
+		pieces               = generatePieces(pieceCount, pieceLength)
+		enc                  = binary.NewBinaryRLNCEncoder(pieces)
+	)
+
+	coded := make([]*kodr_internals.CodedPiece, 0, codedPieceCount)
+	for range codedPieceCount {
+		coded = append(coded, enc.CodedPiece())
+	}
+
+	rec := binary.NewBinaryRLNCRecoder(coded)
+	decoderFlow(t, rec.CodedPiece, pieceCount, pieces)
+}
+
+func TestNewBinaryRLNCRecoderWithFlattenData(t *testing.T) {
+	var (
+		pieceCount      uint = 100
+		pieceLength     uint = 8192
+		codedPieceCount      = pieceCount + 16
+		pieces               = generatePieces(pieceCount, pieceLength)
+		enc                  = binary.NewBinaryRLNCEncoder(pieces)
+	)
+
+	codedFlattened := make([]byte, 0)
+	for range codedPieceCount {
+		codedFlattened = append(codedFlattened, enc.CodedPiece().Flatten()...)
+	}
+
+	rec, err := binary.NewBinaryRLNCRecoderWithFlattenData(codedFlattened, codedPieceCount, pieceCount)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	decoderFlow(t, rec.CodedPiece, pieceCount, pieces)
+}
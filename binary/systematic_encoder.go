@@ -0,0 +1,109 @@
+package binary
+
+import (
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf2"
+)
+
+type BinarySystematicRLNCEncoder struct {
+	currentPieceId uint
+	pieces         []kodr_internals.Piece
+	extra          uint
+}
+
+// Total #-of pieces being coded together --- denoting
+// these many linearly independent pieces are required
+// successfully decoding back to original pieces
+func (b *BinarySystematicRLNCEncoder) PieceCount() uint {
+	return uint(len(b.pieces))
+}
+
+// Pieces which are coded together are all of same size
+func (b *BinarySystematicRLNCEncoder) PieceSize() uint {
+	return uint(len(b.pieces[0]))
+}
+
+// How many bytes of data, constructed by concatenating
+// coded pieces together, required at minimum for decoding
+// back to original pieces ?
+func (b *BinarySystematicRLNCEncoder) DecodableLen() uint {
+	return b.PieceCount() * b.CodedPieceLen()
+}
+
+// Length of one coded piece, where coding vector is
+// bit-packed, taking ceil(N/8) bytes
+func (b *BinarySystematicRLNCEncoder) CodedPieceLen() uint {
+	return gf2.VectorLen(b.PieceCount()) + b.PieceSize()
+}
+
+// If any extra padding bytes added at end of original
+// data slice for making all pieces of same size,
+// returned value will be >0
+func (b *BinarySystematicRLNCEncoder) Padding() uint {
+	return b.extra
+}
+
+// First N-pieces are returned in uncoded form i.e. coding
+// vector has only one bit set, at respective index of piece
+//
+// Later pieces are coded as they're done in binary RLNC scheme
+func (b *BinarySystematicRLNCEncoder) CodedPiece() *kodr_internals.CodedPiece {
+	if b.currentPieceId < b.PieceCount() {
+		vector := gf2.Unit(b.PieceCount(), b.currentPieceId)
+		piece := make(kodr_internals.Piece, b.PieceSize())
+		copy(piece, b.pieces[b.currentPieceId])
+
+		b.currentPieceId++
+		return &kodr_internals.CodedPiece{
+			Vector: vector,
+			Piece:  piece,
+		}
+	}
+
+	vector := gf2.Random(b.PieceCount())
+	piece := make(kodr_internals.Piece, b.PieceSize())
+	for i := range b.pieces {
+		if gf2.Get(vector, uint(i)) {
+			gf2.Xor(piece, b.pieces[i])
+		}
+	}
+
+	return &kodr_internals.CodedPiece{
+		Vector: vector,
+		Piece:  piece,
+	}
+}
+
+// When you've already splitted original data chunk into pieces
+// of same length ( in terms of bytes ), this function can be used
+// for creating one binary systematic RLNC encoder
+func NewBinarySystematicRLNCEncoder(pieces []kodr_internals.Piece) *BinarySystematicRLNCEncoder {
+	return &BinarySystematicRLNCEncoder{currentPieceId: 0, pieces: pieces}
+}
+
+// If you know #-of pieces you want to code together, invoking
+// this function splits whole data chunk into N-pieces, with padding
+// bytes appended at end of last piece, if required
+func NewBinarySystematicRLNCEncoderWithPieceCount(data []byte, pieceCount uint) (*BinarySystematicRLNCEncoder, error) {
+	pieces, padding, err := kodr_internals.OriginalPiecesFromDataAndPieceCount(data, pieceCount)
+	if err != nil {
+		return nil, err
+	}
+
+	enc := NewBinarySystematicRLNCEncoder(pieces)
+	enc.extra = padding
+	return enc, nil
+}
+
+// If you want to have N-bytes piece size for each, this
+// function generates M-many pieces each of N-bytes size
+func NewBinarySystematicRLNCEncoderWithPieceSize(data []byte, pieceSize uint) (*BinarySystematicRLNCEncoder, error) {
+	pieces, padding, err := kodr_internals.OriginalPiecesFromDataAndPieceSize(data, pieceSize)
+	if err != nil {
+		return nil, err
+	}
+
+	enc := NewBinarySystematicRLNCEncoder(pieces)
+	enc.extra = padding
+	return enc, nil
+}
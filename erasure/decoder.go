@@ -0,0 +1,299 @@
+package erasure
+
+import (
+	"encoding/binary"
+	"errors"
+	"slices"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+	"github.com/itzmeanjan/kodr/kodr_internals/matrix"
+)
+
+type ErasureDecoder struct {
+	generator *Generator
+	sources   []kodr_internals.Piece
+	known     uint
+	repairs   []uint
+	received  map[uint]kodr_internals.Piece
+	decoded   bool
+	inverses  *matrix.InverseCache
+}
+
+// #-of inverses, decoder created by `NewErasureDecoder` keeps
+// around, for erasure patterns it has seen most recently
+const DefaultInverseCacheCapacity uint = 1 << 6
+
+// IsDecoded - Use it for checking whether more piece
+// collection is required or not
+func (d *ErasureDecoder) IsDecoded() bool {
+	return d.decoded
+}
+
+// Required - How many more coded pieces are required
+// for successfully decoding original pieces ?
+func (d *ErasureDecoder) Required() uint {
+	have := d.known + uint(len(d.repairs))
+	if have >= d.generator.PieceCount() {
+		return 0
+	}
+	return d.generator.PieceCount() - have
+}
+
+// AddPiece - Adds a received coded piece, identified by its
+// index in generator matrix. As soon as enough pieces are
+// collected, missing original pieces are reconstructed by
+// inverting square submatrix of generator, formed by rows of received
+// repair pieces & columns of missing original pieces
+//
+// Inverse is cached, keyed by erasure pattern, so that decoding
+// next stripe, having same erasure pattern, only needs a
+// matrix-vector multiplication
+//
+// If repair pieces, selected for decoding, turn out to be linearly
+// dependent, those which don't help are forgotten, while others are kept,
+// so that decoding happens as soon as enough of those are received. If
+// given piece itself doesn't help, error is returned, so that some other
+// piece can be added instead
+func (d *ErasureDecoder) AddPiece(idx uint, piece kodr_internals.Piece) error {
+	if d.IsDecoded() {
+		return kodr.ErrAllUsefulPiecesReceived
+	}
+	if idx >= d.generator.CodedPieceCount() {
+		return kodr.ErrCodedPieceOutOfBound
+	}
+	if _, ok := d.received[idx]; ok {
+		return nil
+	}
+	// all received pieces are of same length, so comparing
+	// with any one of those is enough
+	for _, other := range d.received {
+		if len(other) != len(piece) {
+			return kodr.ErrCodedDataLengthMismatch
+		}
+		break
+	}
+
+	pos, systematic := d.generator.systematicIndex(idx)
+	d.received[idx] = piece
+	if systematic {
+		// generator may carry same unit row more than once, in
+		// which case original piece can already be known
+		if d.sources[pos] != nil {
+			return nil
+		}
+		d.sources[pos] = piece
+		d.known++
+	} else {
+		d.repairs = append(d.repairs, idx)
+	}
+
+	if d.known+uint(len(d.repairs)) < d.generator.PieceCount() {
+		return nil
+	}
+
+	err := d.decode()
+	if errors.Is(err, kodr.ErrSingularMatrix) {
+		// selected repair pieces are linearly dependent, so keeping
+		// only those which help, decoding is attempted again, if
+		// still enough of those are left
+		d.prune()
+		if _, ok := d.received[idx]; !ok {
+			return err
+		}
+		if d.known+uint(len(d.repairs)) < d.generator.PieceCount() {
+			return nil
+		}
+		err = d.decode()
+	}
+	if err != nil {
+		return err
+	}
+
+	d.decoded = true
+	return nil
+}
+
+// Indices of original pieces, which are neither received nor decoded
+func (d *ErasureDecoder) erased() []uint {
+	erased := make([]uint, 0, d.generator.PieceCount()-d.known)
+	for i := range d.sources {
+		if d.sources[i] == nil {
+			erased = append(erased, uint(i))
+		}
+	}
+	return erased
+}
+
+// Forgets received repair pieces, whose generator rows, restricted to
+// columns of erased original pieces, are linearly dependent on those of
+// repair pieces received before them. Such a piece never helps, as it
+// stays dependent, when more original pieces are received
+func (d *ErasureDecoder) prune() {
+	var (
+		f      = field.Default()
+		erased = d.erased()
+		basis  = make([][]byte, 0, len(erased))
+		pivots = make([]uint, 0, len(erased))
+		kept   = d.repairs[:0]
+	)
+
+	for _, idx := range d.repairs {
+		residual := make([]byte, len(erased))
+		for j := range erased {
+			residual[j] = d.generator.rows[idx][erased[j]]
+		}
+
+		// each basis row is zero at pivots of ones kept before it,
+		// so eliminating in same order zeroes residual at all pivots
+		for k := range basis {
+			if c := f.Symbol(residual, pivots[k]); c != 0 {
+				f.MulAddSlice(residual, basis[k], f.Sub(0, c))
+			}
+		}
+
+		pivot := slices.IndexFunc(residual, func(v byte) bool { return v != 0 })
+		if pivot < 0 {
+			delete(d.received, idx)
+			continue
+		}
+
+		inv, _ := f.Inv(f.Symbol(residual, uint(pivot)))
+		f.MulSlice(residual, inv)
+
+		basis = append(basis, residual)
+		pivots = append(pivots, uint(pivot))
+		kept = append(kept, idx)
+	}
+
+	d.repairs = kept
+}
+
+// Serializes erasure pattern i.e. indices of missing original
+// pieces & indices of repair pieces used for recovering those,
+// so that it can be used as cache key
+func erasurePattern(erased, repairs []uint) string {
+	buf := make([]byte, 0, 2*binary.MaxVarintLen64*(len(erased)+1))
+	buf = binary.AppendUvarint(buf, uint64(len(erased)))
+	for _, v := range erased {
+		buf = binary.AppendUvarint(buf, uint64(v))
+	}
+	for _, v := range repairs {
+		buf = binary.AppendUvarint(buf, uint64(v))
+	}
+	return string(buf)
+}
+
+func (d *ErasureDecoder) decode() error {
+	erased := d.erased()
+	if len(erased) == 0 {
+		return nil
+	}
+
+	repairs := slices.Clone(d.repairs[:len(erased)])
+	slices.Sort(repairs)
+
+	key := erasurePattern(erased, repairs)
+	inv, ok := d.inverses.Get(key)
+	if !ok {
+		sub := make(matrix.Matrix, len(repairs))
+		for i := range repairs {
+			sub[i] = make([]byte, len(erased))
+			for j := range erased {
+				sub[i][j] = d.generator.rows[repairs[i]][erased[j]]
+			}
+		}
+
+		var err error
+		if inv, err = sub.Inverse(); err != nil {
+			return err
+		}
+		d.inverses.Put(key, inv)
+	}
+
+	pieceSize := len(d.received[repairs[0]])
+
+	// remove contribution of already known original pieces
+	// from each of repair pieces
+	rhs := make([]kodr_internals.Piece, len(repairs))
+	for i := range repairs {
+		rhs[i] = make(kodr_internals.Piece, pieceSize)
+		copy(rhs[i], d.received[repairs[i]])
+
+		row := d.generator.rows[repairs[i]]
+		for j := range d.sources {
+			if d.sources[j] == nil || row[j] == 0 {
+				continue
+			}
+			rhs[i].Multiply(d.sources[j], row[j])
+		}
+	}
+
+	for i := range erased {
+		piece := make(kodr_internals.Piece, pieceSize)
+		for j := range rhs {
+			if inv[i][j] == 0 {
+				continue
+			}
+			piece.Multiply(rhs[j], inv[i][j])
+		}
+		d.sources[erased[i]] = piece
+	}
+
+	d.known = d.generator.PieceCount()
+	return nil
+}
+
+// GetPiece - Get an original piece by index, which is available
+// either if it's received uncoded or decoding has happened
+func (d *ErasureDecoder) GetPiece(i uint) (kodr_internals.Piece, error) {
+	if i >= d.generator.PieceCount() {
+		return nil, kodr.ErrPieceOutOfBound
+	}
+	if d.sources[i] == nil {
+		return nil, kodr.ErrPieceNotDecodedYet
+	}
+
+	return d.sources[i], nil
+}
+
+// GetPieces - Get a list of all original pieces, given decoding
+// has happened
+func (d *ErasureDecoder) GetPieces() ([]kodr_internals.Piece, error) {
+	if !d.IsDecoded() {
+		return nil, kodr.ErrMoreUsefulPiecesRequired
+	}
+
+	return d.sources, nil
+}
+
+// Reset - Forgets all received pieces, so that decoder can be
+// used for reconstructing next stripe, coded using same generator
+// matrix, while keeping already computed inverses around
+func (d *ErasureDecoder) Reset() {
+	d.sources = make([]kodr_internals.Piece, d.generator.PieceCount())
+	d.known = 0
+	d.repairs = d.repairs[:0]
+	d.received = make(map[uint]kodr_internals.Piece)
+	d.decoded = false
+}
+
+// Provide with generator matrix, which was used by encoder, to get a
+// decoder, which only accepts ( index, payload ) pairs & reconstructs
+// missing original pieces
+//
+// Inverses computed for at max `DefaultInverseCacheCapacity` many most
+// recently used erasure patterns are kept around
+func NewErasureDecoder(generator *Generator) *ErasureDecoder {
+	return NewErasureDecoderWithCache(generator, matrix.NewInverseCache(DefaultInverseCacheCapacity, matrix.EvictLRU))
+}
+
+// Same as `NewErasureDecoder`, but computed inverses are kept in given
+// cache, which can be shared among decoders of many stripes. Don't share
+// one cache among decoders working with different generator matrices
+func NewErasureDecoderWithCache(generator *Generator, cache *matrix.InverseCache) *ErasureDecoder {
+	dec := &ErasureDecoder{generator: generator, inverses: cache}
+	dec.Reset()
+	return dec
+}
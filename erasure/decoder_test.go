@@ -0,0 +1,195 @@
+package erasure_test
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/erasure"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/matrix"
+)
+
+func decoderFlow(t *testing.T, enc *erasure.ErasureEncoder, dec *erasure.ErasureDecoder, pieces []kodr_internals.Piece, order []int) {
+	for _, idx := range order {
+		c_piece, err := enc.CodedPiece(uint(idx))
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		if err := dec.AddPiece(uint(idx), c_piece.Piece); errors.Is(err, kodr.ErrAllUsefulPiecesReceived) {
+			break
+		} else if err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	if !dec.IsDecoded() {
+		t.Fatal("expected to be fully decoded !")
+	}
+
+	d_pieces, err := dec.GetPieces()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	for i := range pieces {
+		if !bytes.Equal(pieces[i], d_pieces[i]) {
+			t.Fatal("decoded data doesn't match !")
+		}
+	}
+}
+
+func TestErasureDecoder(t *testing.T) {
+	var (
+		pieceCount  uint = 32
+		repairCount uint = 16
+	)
+
+	gen, err := erasure.NewSystematicGenerator(pieceCount, repairCount)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	dec := erasure.NewErasureDecoder(gen)
+	if _, err := dec.GetPieces(); !(err != nil && errors.Is(err, kodr.ErrMoreUsefulPiecesRequired)) {
+		t.Fatalf("expected: %s\n", kodr.ErrMoreUsefulPiecesRequired)
+	}
+	if err := dec.AddPiece(pieceCount+repairCount, nil); !(err != nil && errors.Is(err, kodr.ErrCodedPieceOutOfBound)) {
+		t.Fatalf("expected: %s\n", kodr.ErrCodedPieceOutOfBound)
+	}
+
+	t.Run("RandomErasures", func(t *testing.T) {
+		for range 1 << 4 {
+			pieces := generatePieces(pieceCount, 1<<8)
+			enc, err := erasure.NewErasureEncoder(pieces, gen)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+
+			dec.Reset()
+			decoderFlow(t, enc, dec, pieces, rand.Perm(int(pieceCount+repairCount)))
+		}
+	})
+
+	// many stripes, all losing same original pieces, which is
+	// where cached inverse gets reused
+	t.Run("SameErasurePattern", func(t *testing.T) {
+		order := rand.Perm(int(pieceCount + repairCount))
+		for range 1 << 4 {
+			pieces := generatePieces(pieceCount, 1<<8)
+			enc, err := erasure.NewErasureEncoder(pieces, gen)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+
+			dec.Reset()
+			decoderFlow(t, enc, dec, pieces, order)
+		}
+	})
+
+	t.Run("AllRepairPieces", func(t *testing.T) {
+		pieces := generatePieces(repairCount, 1<<8)
+		gen, err := erasure.NewSystematicGenerator(repairCount, repairCount)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		enc, err := erasure.NewErasureEncoder(pieces, gen)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		order := make([]int, 0, repairCount)
+		for i := range repairCount {
+			order = append(order, int(repairCount+i))
+		}
+		decoderFlow(t, enc, erasure.NewErasureDecoder(gen), pieces, order)
+	})
+}
+
+// Generator, which isn't MDS, so that some received pieces don't help
+func nonMDSFlow(t *testing.T, rows matrix.Matrix) (*erasure.ErasureEncoder, *erasure.ErasureDecoder, []kodr_internals.Piece, func(uint) error) {
+	gen, err := erasure.NewGenerator(rows, 2)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	pieces := generatePieces(2, 1<<8)
+	enc, err := erasure.NewErasureEncoder(pieces, gen)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	dec := erasure.NewErasureDecoder(gen)
+	add := func(idx uint) error {
+		c_piece, err := enc.CodedPiece(idx)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		return dec.AddPiece(idx, c_piece.Piece)
+	}
+	return enc, dec, pieces, add
+}
+
+func TestErasureDecoderUselessPiece(t *testing.T) {
+	t.Run("UselessRepairPiece", func(t *testing.T) {
+		// last row isn't a unit vector, so it's a repair piece, which
+		// carries only second original piece
+		enc, dec, pieces, add := nonMDSFlow(t, matrix.Matrix{{1, 0}, {0, 1}, {0, 2}})
+
+		if err := add(2); err != nil {
+			t.Fatal(err.Error())
+		}
+		if err := dec.AddPiece(0, make(kodr_internals.Piece, 1<<7)); !errors.Is(err, kodr.ErrCodedDataLengthMismatch) {
+			t.Fatalf("expected: %s\n", kodr.ErrCodedDataLengthMismatch)
+		}
+
+		// second original piece is kept, while repair piece, which can't
+		// help recovering first one, is forgotten
+		if err := add(1); err != nil {
+			t.Fatal(err.Error())
+		}
+		if piece, err := dec.GetPiece(1); err != nil || !bytes.Equal(piece, pieces[1]) {
+			t.Fatal("expected second original piece to be available")
+		}
+		if dec.IsDecoded() || dec.Required() != 1 {
+			t.Fatalf("expected 1 more piece to be required, but %d", dec.Required())
+		}
+
+		decoderFlow(t, enc, dec, pieces, []int{0})
+	})
+
+	t.Run("DependentRepairPieces", func(t *testing.T) {
+		enc, dec, pieces, add := nonMDSFlow(t, matrix.Matrix{{1, 0}, {0, 1}, {1, 1}, {2, 2}})
+
+		if err := add(2); err != nil {
+			t.Fatal(err.Error())
+		}
+		if err := add(3); !errors.Is(err, kodr.ErrSingularMatrix) {
+			t.Fatalf("expected: %s, received: %v\n", kodr.ErrSingularMatrix, err)
+		}
+		if dec.Required() != 1 {
+			t.Fatalf("expected 1 more piece to be required, but %d", dec.Required())
+		}
+
+		decoderFlow(t, enc, dec, pieces, []int{1})
+	})
+
+	t.Run("DuplicateUnitRows", func(t *testing.T) {
+		enc, dec, pieces, add := nonMDSFlow(t, matrix.Matrix{{1, 0}, {0, 1}, {1, 0}, {1, 1}})
+
+		for _, idx := range []uint{0, 2} {
+			if err := add(idx); err != nil {
+				t.Fatal(err.Error())
+			}
+		}
+		if dec.IsDecoded() || dec.Required() != 1 {
+			t.Fatalf("expected 1 more piece to be required, but %d", dec.Required())
+		}
+
+		decoderFlow(t, enc, dec, pieces, []int{3})
+	})
+}
@@ -0,0 +1,92 @@
+package erasure
+
+import (
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+)
+
+type ErasureEncoder struct {
+	pieces    []kodr_internals.Piece
+	generator *Generator
+	extra     uint
+}
+
+// Total #-of pieces being coded together
+func (e *ErasureEncoder) PieceCount() uint {
+	return uint(len(e.pieces))
+}
+
+// Pieces which are coded together are all of same size
+func (e *ErasureEncoder) PieceSize() uint {
+	return uint(len(e.pieces[0]))
+}
+
+// How many extra padding bytes added at end of
+// original data slice so that splitted pieces are
+// all of same size ?
+func (e *ErasureEncoder) Padding() uint {
+	return e.extra
+}
+
+// Generator matrix being used for coding, which
+// decoder also needs to know about
+func (e *ErasureEncoder) Generator() *Generator {
+	return e.generator
+}
+
+// Returns coded piece at index `idx` i.e. original pieces
+// combined using row `idx` of generator matrix
+//
+// Only payload ( read `Piece` ) of returned coded piece
+// along with `idx` needs to be sent to decoder, because
+// coding vector is already known to it
+func (e *ErasureEncoder) CodedPiece(idx uint) (*kodr_internals.CodedPiece, error) {
+	row, err := e.generator.Row(idx)
+	if err != nil {
+		return nil, err
+	}
+
+	vector := make(kodr_internals.CodingVector, len(row))
+	copy(vector, row)
+
+	piece := make(kodr_internals.Piece, e.PieceSize())
+	for i := range e.pieces {
+		if vector[i] == 0 {
+			continue
+		}
+		piece.Multiply(e.pieces[i], vector[i])
+	}
+
+	return &kodr_internals.CodedPiece{
+		Vector: vector,
+		Piece:  piece,
+	}, nil
+}
+
+// Provide with original pieces & generator matrix ( which
+// both encoder & decoder agree upon ) to get an encoder
+func NewErasureEncoder(pieces []kodr_internals.Piece, generator *Generator) (*ErasureEncoder, error) {
+	if uint(len(pieces)) != generator.PieceCount() {
+		return nil, kodr.ErrGeneratorDimensionMismatch
+	}
+
+	return &ErasureEncoder{pieces: pieces, generator: generator}, nil
+}
+
+// Splits whole data chunk into N-pieces, with padding bytes
+// appended at end of last piece, if required & prepares encoder,
+// where N = pieceCount of generator matrix
+func NewErasureEncoderWithData(data []byte, generator *Generator) (*ErasureEncoder, error) {
+	pieces, padding, err := kodr_internals.OriginalPiecesFromDataAndPieceCount(data, generator.PieceCount())
+	if err != nil {
+		return nil, err
+	}
+
+	enc, err := NewErasureEncoder(pieces, generator)
+	if err != nil {
+		return nil, err
+	}
+
+	enc.extra = padding
+	return enc, nil
+}
@@ -0,0 +1,99 @@
+package erasure_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/erasure"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/matrix"
+)
+
+// Generates `N`-bytes of random data from default
+// randomization source
+func generateData(n uint) []byte {
+	data := make([]byte, n)
+	rand.Read(data)
+	return data
+}
+
+// Generates N-many pieces each of M-bytes length, to be used
+// for testing purposes
+func generatePieces(pieceCount uint, pieceLength uint) []kodr_internals.Piece {
+	pieces := make([]kodr_internals.Piece, 0, pieceCount)
+	for range pieceCount {
+		pieces = append(pieces, generateData(pieceLength))
+	}
+	return pieces
+}
+
+func TestNewSystematicGenerator(t *testing.T) {
+	if _, err := erasure.NewSystematicGenerator(200, 57); !(err != nil && errors.Is(err, kodr.ErrTooManyPiecesForGenerator)) {
+		t.Fatalf("expected: %s\n", kodr.ErrTooManyPiecesForGenerator)
+	}
+
+	var (
+		pieceCount  uint = 16
+		repairCount uint = 8
+	)
+
+	gen, err := erasure.NewSystematicGenerator(pieceCount, repairCount)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if gen.CodedPieceCount() != pieceCount+repairCount {
+		t.Fatalf("expected %d rows, found %d\n", pieceCount+repairCount, gen.CodedPieceCount())
+	}
+
+	// any square submatrix of Cauchy matrix must be invertible
+	for i := range repairCount - 1 {
+		r_0, _ := gen.Row(pieceCount + i)
+		r_1, _ := gen.Row(pieceCount + i + 1)
+
+		sub := matrix.Matrix{{r_0[i], r_0[i+1]}, {r_1[i], r_1[i+1]}}
+		if _, err := sub.Inverse(); err != nil {
+			t.Fatalf("expected invertible submatrix, found %s\n", err)
+		}
+	}
+}
+
+func TestErasureEncoder(t *testing.T) {
+	var (
+		pieceCount  uint = 32
+		repairCount uint = 16
+		pieces           = generatePieces(pieceCount, 1<<10)
+	)
+
+	gen, err := erasure.NewSystematicGenerator(pieceCount, repairCount)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if _, err := erasure.NewErasureEncoder(pieces[1:], gen); !(err != nil && errors.Is(err, kodr.ErrGeneratorDimensionMismatch)) {
+		t.Fatalf("expected: %s\n", kodr.ErrGeneratorDimensionMismatch)
+	}
+
+	enc, err := erasure.NewErasureEncoder(pieces, gen)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if _, err := enc.CodedPiece(pieceCount + repairCount); !(err != nil && errors.Is(err, kodr.ErrCodedPieceOutOfBound)) {
+		t.Fatalf("expected: %s\n", kodr.ErrCodedPieceOutOfBound)
+	}
+
+	for i := range pieceCount {
+		c_piece, err := enc.CodedPiece(i)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		if !c_piece.IsSystematic() || !bytes.Equal(c_piece.Piece, pieces[i]) {
+			t.Fatalf("expected coded piece %d to be uncoded\n", i)
+		}
+	}
+}
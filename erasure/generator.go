@@ -0,0 +1,117 @@
+package erasure
+
+import (
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf256"
+	"github.com/itzmeanjan/kodr/kodr_internals/matrix"
+)
+
+// Generator matrix, both encoder & decoder agree upon,
+// where each row is coding vector of coded piece
+// with same index
+//
+// Because coding vectors are known to both sides,
+// only ( index, payload ) pairs need to be exchanged
+type Generator struct {
+	pieceCount uint
+	rows       matrix.Matrix
+}
+
+// #-of original pieces being coded together
+func (g *Generator) PieceCount() uint {
+	return g.pieceCount
+}
+
+// #-of coded pieces which can be produced, using
+// this generator matrix
+func (g *Generator) CodedPieceCount() uint {
+	return g.rows.Rows()
+}
+
+// Coding vector for coded piece at index `idx`
+func (g *Generator) Row(idx uint) ([]byte, error) {
+	if idx >= g.CodedPieceCount() {
+		return nil, kodr.ErrCodedPieceOutOfBound
+	}
+
+	return g.rows[idx], nil
+}
+
+// If row at index `idx` is a unit vector, returns index
+// of original piece it carries ( uncoded ) & true
+func (g *Generator) systematicIndex(idx uint) (uint, bool) {
+	pos := -1
+	for i, v := range g.rows[idx] {
+		switch v {
+		case 0:
+			continue
+
+		case 1:
+			if pos != -1 {
+				return 0, false
+			}
+			pos = i
+
+		default:
+			return 0, false
+
+		}
+	}
+
+	return uint(pos), pos >= 0
+}
+
+// Wraps any known generator matrix ( say MDS ), where
+// each row has `pieceCount` columns & there are at least
+// `pieceCount` rows
+func NewGenerator(rows matrix.Matrix, pieceCount uint) (*Generator, error) {
+	if rows.Rows() < pieceCount {
+		return nil, kodr.ErrGeneratorDimensionMismatch
+	}
+
+	for i := range rows {
+		if uint(len(rows[i])) != pieceCount {
+			return nil, kodr.ErrGeneratorDimensionMismatch
+		}
+	}
+
+	return &Generator{pieceCount: pieceCount, rows: rows}, nil
+}
+
+// Systematic MDS generator matrix, where first `pieceCount` rows
+// form identity matrix & next `repairCount` rows are taken from
+// a Cauchy matrix, so that any `pieceCount` coded pieces are enough
+// for reconstructing original pieces
+//
+// Note: pieceCount + repairCount must be <= 256, as elements
+// used for constructing Cauchy matrix need to be distinct in GF(2^8)
+func NewSystematicGenerator(pieceCount, repairCount uint) (*Generator, error) {
+	if pieceCount < 2 {
+		return nil, kodr.ErrBadPieceCount
+	}
+	if pieceCount+repairCount > 256 {
+		return nil, kodr.ErrTooManyPiecesForGenerator
+	}
+
+	rows := make(matrix.Matrix, pieceCount+repairCount)
+	for i := range pieceCount {
+		rows[i] = make([]byte, pieceCount)
+		rows[i][i] = 1
+	}
+
+	// C[i][j] = 1 / ( x_i + y_j ), where x_i = i & y_j = repairCount + j
+	for i := range repairCount {
+		row := make([]byte, pieceCount)
+		for j := range pieceCount {
+			x := gf256.New(uint8(i))
+			y := gf256.New(uint8(repairCount + j))
+
+			// x_i != y_j, so their sum is never zero
+			inv, _ := x.Add(y).Inv()
+			row[j] = inv.Get()
+		}
+		rows[pieceCount+i] = row
+	}
+
+	return &Generator{pieceCount: pieceCount, rows: rows}, nil
+}
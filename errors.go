@@ -3,16 +3,46 @@ package kodr
 import "errors"
 
 var (
-	ErrCannotInvertGf256AdditiveIndentity = errors.New("additive identity of Gf(2^8) i.e. 0, doesn't have a multiplicative inverse")
-	ErrMatrixDimensionMismatch            = errors.New("can't perform matrix multiplication")
-	ErrAllUsefulPiecesReceived            = errors.New("no more pieces required for decoding")
-	ErrMoreUsefulPiecesRequired           = errors.New("not enough pieces received yet to decode")
-	ErrCopyFailedDuringPieceConstruction  = errors.New("failed to copy whole data before splitting into pieces")
-	ErrPieceCountMoreThanTotalBytes       = errors.New("requested piece count > total bytes of original data")
-	ErrZeroPieceSize                      = errors.New("pieces can't be sized as zero byte")
-	ErrBadPieceCount                      = errors.New("minimum 2 pieces required for RLNC")
-	ErrCodedDataLengthMismatch            = errors.New("coded data length != coded piece count x coded piece length")
-	ErrCodingVectorLengthMismatch         = errors.New("coding vector length > coded piece length ( in total )")
-	ErrPieceNotDecodedYet                 = errors.New("piece not decoded yet, more pieces required")
-	ErrPieceOutOfBound                    = errors.New("requested piece index >= pieceCount ( pieces coded together )")
+	ErrCannotInvertGf256AdditiveIndentity  = errors.New("additive identity of Gf(2^8) i.e. 0, doesn't have a multiplicative inverse")
+	ErrMatrixDimensionMismatch             = errors.New("can't perform matrix multiplication")
+	ErrAllUsefulPiecesReceived             = errors.New("no more pieces required for decoding")
+	ErrMoreUsefulPiecesRequired            = errors.New("not enough pieces received yet to decode")
+	ErrCopyFailedDuringPieceConstruction   = errors.New("failed to copy whole data before splitting into pieces")
+	ErrPieceCountMoreThanTotalBytes        = errors.New("requested piece count > total bytes of original data")
+	ErrZeroPieceSize                       = errors.New("pieces can't be sized as zero byte")
+	ErrBadPieceCount                       = errors.New("minimum 2 pieces required for RLNC")
+	ErrCodedDataLengthMismatch             = errors.New("coded data length != coded piece count x coded piece length")
+	ErrCodingVectorLengthMismatch          = errors.New("coding vector length > coded piece length ( in total )")
+	ErrPieceNotDecodedYet                  = errors.New("piece not decoded yet, more pieces required")
+	ErrPieceOutOfBound                     = errors.New("requested piece index >= pieceCount ( pieces coded together )")
+	ErrSingularMatrix                      = errors.New("matrix is singular, doesn't have an inverse")
+	ErrCodedPieceOutOfBound                = errors.New("requested coded piece index >= #-of rows in generator matrix")
+	ErrTooManyPiecesForGenerator           = errors.New("source + repair piece count > field order, can't construct MDS generator")
+	ErrGeneratorDimensionMismatch          = errors.New("generator matrix must have >= pieceCount rows, each with pieceCount columns")
+	ErrCannotInvertGf65536AdditiveIdentity = errors.New("additive identity of Gf(2^16) i.e. 0, doesn't have a multiplicative inverse")
+	ErrPieceSizeNotMultipleOfSymbolSize    = errors.New("piece size must be a multiple of symbol size of finite field")
+	ErrInvalidGf256Polynomial              = errors.New("polynomial for constructing Gf(2^8) must be of degree 8")
+	ErrNonPrimitiveGf256Generator          = errors.New("generator isn't a primitive element of Gf(2^8), or polynomial isn't irreducible")
+	ErrFieldMismatch                       = errors.New("coded piece is coded over a different finite field than decoder's")
+	ErrBadBandWidth                        = errors.New("band width must be in [1, pieceCount]")
+	ErrBadDistributionParameters           = errors.New("degree distribution requires K > 0, c > 0 & delta in (0, 1)")
+	ErrMalformedFeedback                   = errors.New("feedback isn't a serialized rank of decoder")
+	ErrBadPrecodeParameters                = errors.New("precode requires at least 1 source & 1 parity piece")
+	ErrPieceCountMismatch                  = errors.New("#-of pieces != #-of pieces expected")
+	ErrBadWindows                          = errors.New("window sizes must be strictly increasing & last one must be pieceCount")
+	ErrBadWindowProbabilities              = errors.New("one non-negative probability per window required, not all zero")
+	ErrWindowOutOfBound                    = errors.New("requested window index >= #-of windows")
+	ErrSummaryMismatch                     = errors.New("subspace summary is for different #-of pieces or finite field")
+	ErrNoInnovativePiece                   = errors.New("recoder holds no piece, which is innovative for receiver")
+	ErrMalformedSummary                    = errors.New("flattened subspace summary is malformed")
+	ErrDecoderMismatch                     = errors.New("decoders differ in #-of pieces, finite field, piece length or kind of state")
+	ErrMalformedBitmap                     = errors.New("bitmap isn't ⌈pieceCount/8⌉ bytes long or has bits set beyond pieceCount")
+	ErrBadBufferCapacity                   = errors.New("recoder buffer must be able to hold at least 1 piece")
+	ErrBadFanIn                            = errors.New("recoded piece must combine at least 1 held piece")
+	ErrVersionMismatch                     = errors.New("coded piece is coded from different version of object than expected")
+	ErrPieceSizeMismatch                   = errors.New("updated piece must be of same size as original pieces")
+	ErrCoefficientCountMismatch            = errors.New("#-of coefficients != #-of coded pieces being combined")
+	ErrEmptyCombination                    = errors.New("linear combination requires at least 1 coded piece")
+	ErrCodingVectorPaddingNotZero          = errors.New("bit-packed coding vector has bits set beyond pieceCount")
+	ErrCoefficientOutOfField               = errors.New("coefficient isn't an element of finite field")
 )
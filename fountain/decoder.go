@@ -0,0 +1,325 @@
+package fountain
+
+import (
+	"math"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+	"github.com/itzmeanjan/kodr/kodr_internals/matrix"
+)
+
+// Received coded piece, which still has more than one unknown
+// original piece, waiting to be peeled
+type pending struct {
+	neighbours []uint
+	coeffs     []byte
+	degree     uint
+	piece      kodr_internals.Piece
+}
+
+// Removes contribution of decoded original piece at index `idx`
+func (p *pending) resolve(f field.Field, idx uint, decoded kodr_internals.Piece) {
+	for k, n := range p.neighbours {
+		if n != idx || p.coeffs[k] == 0 {
+			continue
+		}
+
+		f.MulAddSlice(p.piece, decoded, uint32(p.coeffs[k]))
+		p.coeffs[k] = 0
+		p.degree--
+		return
+	}
+}
+
+// Decoder, which peels first i.e. keeps resolving coded pieces having
+// only one unknown original piece & substituting it in all other coded
+// pieces, which costs O(degree) row operations per coded piece
+//
+// When peeling stalls i.e. no coded piece with one unknown is left, while
+// enough coded pieces are received ( beyond fallback threshold ), remaining
+// ones are solved by inactivation decoding, through `SparseDecoderState`,
+// over unknown pieces only. If it fails, it's not retried, until as many
+// more coded pieces are received, as rank fell short by
+//
+// Though coding vectors produced by LT encoder are binary, any GF(2^8)
+// coefficients are handled
+type LTDecoder struct {
+	pieceCount, received uint
+	constraints          uint
+	pieceLength          uint
+	threshold, retry     uint
+	decoded              []kodr_internals.Piece
+	solved               uint
+	pending              []*pending
+	alive                uint
+	adjacency            [][]int
+	ripple               []int
+	peeled, eliminated   uint
+}
+
+// IsDecoded - Use it for checking whether more piece
+// collection is required or not
+func (d *LTDecoder) IsDecoded() bool {
+	return d.solved == d.pieceCount
+}
+
+// Required - At least how many more coded pieces are required
+// for successfully decoding pieces ?
+//
+// Note: Coded pieces waiting to be peeled may turn out to be linearly
+// dependent, which is why returned value is only a lower bound
+func (d *LTDecoder) Required() uint {
+	if d.IsDecoded() {
+		return 0
+	}
+	if unknown := d.pieceCount - d.solved; d.alive < unknown {
+		return unknown - d.alive
+	}
+	return 1
+}
+
+// Received - How many coded pieces are received so far, including
+// linearly dependent ones
+func (d *LTDecoder) Received() uint {
+	return d.received
+}
+
+// Stats - #-of original pieces decoded by peeling & by
+// Gaussian elimination, respectively
+func (d *LTDecoder) Stats() (uint, uint) {
+	return d.peeled, d.eliminated
+}
+
+// Marks original piece at index `idx` decoded & substitutes it in
+// all pending coded pieces it's part of, adding those having only
+// one unknown left, to ripple
+func (d *LTDecoder) solve(idx uint, piece kodr_internals.Piece) {
+	f := field.Default()
+	d.decoded[idx] = piece
+	d.solved++
+
+	for _, p := range d.adjacency[idx] {
+		pnd := d.pending[p]
+		if pnd == nil {
+			continue
+		}
+
+		pnd.resolve(f, idx, piece)
+		if pnd.degree <= 1 {
+			d.ripple = append(d.ripple, p)
+		}
+	}
+	d.adjacency[idx] = nil
+}
+
+// Keeps peeling, until ripple is empty
+func (d *LTDecoder) peel() {
+	f := field.Default()
+
+	for len(d.ripple) > 0 {
+		p := d.ripple[len(d.ripple)-1]
+		d.ripple = d.ripple[:len(d.ripple)-1]
+
+		pnd := d.pending[p]
+		if pnd == nil {
+			continue
+		}
+		d.pending[p] = nil
+		d.alive--
+
+		if pnd.degree == 0 {
+			continue
+		}
+
+		for k, n := range pnd.neighbours {
+			if pnd.coeffs[k] == 0 {
+				continue
+			}
+
+			inv, _ := f.Inv(uint32(pnd.coeffs[k]))
+			f.MulSlice(pnd.piece, inv)
+			d.peeled++
+			d.solve(n, pnd.piece)
+			break
+		}
+	}
+}
+
+// Solves unknown original pieces, using pending coded pieces, by Gaussian
+// elimination. Original pieces, which are revealed, are substituted back,
+// so that peeling can resume, even if not all are revealed
+func (d *LTDecoder) eliminate() {
+	unknown := make([]uint, 0, d.pieceCount-d.solved)
+	column := make([]int, d.pieceCount)
+	for i := range d.pieceCount {
+		column[i] = -1
+		if d.decoded[i] == nil {
+			column[i] = len(unknown)
+			unknown = append(unknown, i)
+		}
+	}
+
+	state := matrix.NewSparseDecoderStateWithPieceCount(uint(len(unknown)))
+	for _, pnd := range d.pending {
+		if pnd == nil {
+			continue
+		}
+
+		vector := make(kodr_internals.CodingVector, len(unknown))
+		for k, n := range pnd.neighbours {
+			if pnd.coeffs[k] != 0 {
+				vector[column[n]] = pnd.coeffs[k]
+			}
+		}
+		state.AddPiece(&kodr_internals.CodedPiece{Vector: vector, Piece: pnd.piece})
+	}
+	state.Rref()
+	deficit := uint(len(unknown)) - state.Rank()
+
+	for k, idx := range unknown {
+		piece, err := state.GetPiece(uint(k))
+		if err != nil || d.decoded[idx] != nil {
+			continue
+		}
+
+		d.eliminated++
+		d.solve(idx, piece)
+	}
+
+	d.peel()
+
+	// each coded piece raises rank by at most one, so elimination
+	// can't succeed before these many more are pending
+	d.retry = uint(len(d.pending)) + deficit
+}
+
+// AddPiece - Adds a new received coded piece, substituting already
+// decoded original pieces in it, then peels as far as possible. If
+// peeling stalls, after fallback threshold is crossed, while there are at
+// least as many pending coded pieces as unknown original pieces, falls
+// back to Gaussian elimination
+//
+// Note: Coded piece is copied, caller's slices are never modified
+func (d *LTDecoder) AddPiece(piece *kodr_internals.CodedPiece) error {
+	if d.IsDecoded() {
+		return kodr.ErrAllUsefulPiecesReceived
+	}
+	if uint(len(piece.Vector)) != d.pieceCount {
+		return kodr.ErrCodingVectorLengthMismatch
+	}
+	if d.received+d.constraints > 0 && uint(len(piece.Piece)) != d.pieceLength {
+		return kodr.ErrCodedDataLengthMismatch
+	}
+
+	d.received++
+	d.add(piece.Vector, piece.Piece)
+	return nil
+}
+
+// AddConstraint - Adds a known linear constraint among original pieces
+// i.e. combination of original pieces, as per coding vector, is zero,
+// which is treated same as a received coded piece, carrying zero piece
+//
+// Precode's parity checks are such constraints, which let decoder
+// succeed with fewer received coded pieces
+func (d *LTDecoder) AddConstraint(vector kodr_internals.CodingVector, pieceLength uint) error {
+	if d.IsDecoded() {
+		return kodr.ErrAllUsefulPiecesReceived
+	}
+	if uint(len(vector)) != d.pieceCount {
+		return kodr.ErrCodingVectorLengthMismatch
+	}
+	if d.received+d.constraints > 0 && pieceLength != d.pieceLength {
+		return kodr.ErrCodedDataLengthMismatch
+	}
+
+	d.constraints++
+	d.add(vector, make(kodr_internals.Piece, pieceLength))
+	return nil
+}
+
+func (d *LTDecoder) add(vector kodr_internals.CodingVector, piece kodr_internals.Piece) {
+	f := field.Default()
+	d.pieceLength = uint(len(piece))
+	pnd := &pending{piece: make(kodr_internals.Piece, len(piece))}
+	copy(pnd.piece, piece)
+
+	for i, c := range vector {
+		if c == 0 {
+			continue
+		}
+		if d.decoded[i] != nil {
+			f.MulAddSlice(pnd.piece, d.decoded[i], uint32(c))
+			continue
+		}
+
+		pnd.neighbours = append(pnd.neighbours, uint(i))
+		pnd.coeffs = append(pnd.coeffs, c)
+	}
+	pnd.degree = uint(len(pnd.neighbours))
+
+	if pnd.degree == 0 {
+		return
+	}
+
+	p := len(d.pending)
+	d.pending = append(d.pending, pnd)
+	d.alive++
+	for _, n := range pnd.neighbours {
+		d.adjacency[n] = append(d.adjacency[n], p)
+	}
+
+	if pnd.degree == 1 {
+		d.ripple = append(d.ripple, p)
+	}
+	d.peel()
+
+	if !d.IsDecoded() && d.received+d.constraints >= d.threshold && d.alive >= d.pieceCount-d.solved && uint(len(d.pending)) >= d.retry {
+		d.eliminate()
+	}
+}
+
+// GetPiece - Get a decoded piece by index, may ( not ) succeed !
+//
+// Note: Original pieces are revealed one by one, as peeling progresses,
+// so it's not necessary that full decoding needs to happen
+func (d *LTDecoder) GetPiece(i uint) (kodr_internals.Piece, error) {
+	if i >= d.pieceCount {
+		return nil, kodr.ErrPieceOutOfBound
+	}
+	if d.decoded[i] == nil {
+		return nil, kodr.ErrPieceNotDecodedYet
+	}
+	return d.decoded[i], nil
+}
+
+// GetPieces - Get a list of all decoded pieces, given full
+// decoding has happened
+func (d *LTDecoder) GetPieces() ([]kodr_internals.Piece, error) {
+	if !d.IsDecoded() {
+		return nil, kodr.ErrMoreUsefulPiecesRequired
+	}
+	return d.decoded, nil
+}
+
+// Decoder for N-many pieces, coded by LT encoder, which falls back to
+// Gaussian elimination whenever peeling stalls, with at least as many
+// pending coded pieces as unknown original pieces, so that #-of pieces
+// required stays minimal
+func NewLTDecoder(pieceCount uint) *LTDecoder {
+	return NewLTDecoderWithFallbackOverhead(pieceCount, 0)
+}
+
+// Same as `NewLTDecoder`, but Gaussian elimination is tried only after
+// ( 1 + overhead ) * N coded pieces ( constraints included ) are received
+// & peeling stalls, which suits large generations, where peeling alone
+// mostly succeeds before that, so that elimination is rarely paid for
+func NewLTDecoderWithFallbackOverhead(pieceCount uint, overhead float64) *LTDecoder {
+	return &LTDecoder{
+		pieceCount: pieceCount,
+		threshold:  uint(math.Ceil((1 + max(overhead, 0)) * float64(pieceCount))),
+		decoded:    make([]kodr_internals.Piece, pieceCount),
+		adjacency:  make([][]int, pieceCount),
+	}
+}
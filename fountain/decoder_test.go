@@ -0,0 +1,217 @@
+package fountain_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/fountain"
+	"github.com/itzmeanjan/kodr/full"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+)
+
+// Generates N-many pieces each of M-bytes length, to be used
+// for testing purposes
+func generatePieces(pieceCount uint, pieceLength uint) []kodr_internals.Piece {
+	pieces := make([]kodr_internals.Piece, 0, pieceCount)
+	for range pieceCount {
+		piece := make(kodr_internals.Piece, pieceLength)
+		rand.Read(piece)
+		pieces = append(pieces, piece)
+	}
+	return pieces
+}
+
+// Keeps feeding coded pieces to decoder until it's able to decode,
+// then compares decoded pieces with original ones
+func decoderFlow(t *testing.T, next func() *kodr_internals.CodedPiece, dec *fountain.LTDecoder, pieces []kodr_internals.Piece) *fountain.LTDecoder {
+	for {
+		if err := dec.AddPiece(next()); errors.Is(err, kodr.ErrAllUsefulPiecesReceived) {
+			break
+		} else if err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	d_pieces, err := dec.GetPieces()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	for i := range pieces {
+		if !bytes.Equal(pieces[i], d_pieces[i]) {
+			t.Fatal("decoded data doesn't match !")
+		}
+	}
+
+	return dec
+}
+
+func TestRobustSoliton(t *testing.T) {
+	if _, err := fountain.NewRobustSoliton(100, 0.1, 1); !errors.Is(err, kodr.ErrBadDistributionParameters) {
+		t.Fatalf("expected: %s\n", kodr.ErrBadDistributionParameters)
+	}
+
+	dist, err := fountain.NewRobustSoliton(1000, 0.1, 0.5)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	total := 0.
+	for d := uint(1); d <= 1000; d++ {
+		total += dist.Probability(d)
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Fatalf("probabilities must sum to 1, found %f\n", total)
+	}
+
+	// most of mass lies on small degrees, with degree 2 being most likely
+	if !(dist.Probability(2) > dist.Probability(1) && dist.Probability(2) > dist.Probability(3)) {
+		t.Fatal("expected degree 2 to be most likely")
+	}
+
+	for range 10_000 {
+		if d := dist.Degree(); d < 1 || d > 1000 {
+			t.Fatalf("degree %d out of range\n", d)
+		}
+	}
+}
+
+func TestLTDecoder(t *testing.T) {
+	var (
+		pieceCount  uint = 1024
+		pieceLength uint = 64
+		pieces           = generatePieces(pieceCount, pieceLength)
+	)
+
+	dist, err := fountain.NewRobustSoliton(pieceCount, 0.05, 0.5)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// both decoders are fed same coded pieces, in same order
+	enc := fountain.NewLTEncoder(pieces, dist)
+	coded := make([]*kodr_internals.CodedPiece, 0, 2*pieceCount)
+	replay := func() func() *kodr_internals.CodedPiece {
+		next := 0
+		return func() *kodr_internals.CodedPiece {
+			if next == len(coded) {
+				coded = append(coded, enc.CodedPiece())
+			}
+			next++
+			return coded[next-1]
+		}
+	}
+
+	// elimination is put off, so that peeling does most of work
+	delayed := decoderFlow(t, replay(), fountain.NewLTDecoderWithFallbackOverhead(pieceCount, 0.25), pieces)
+	peeled, eliminated := delayed.Stats()
+	if peeled+eliminated != pieceCount {
+		t.Fatalf("expected %d pieces decoded, found %d peeled & %d eliminated\n", pieceCount, peeled, eliminated)
+	}
+	if peeled < eliminated {
+		t.Fatalf("expected most pieces to be peeled, found %d peeled & %d eliminated\n", peeled, eliminated)
+	}
+	if overhead := float64(delayed.Received())/float64(pieceCount) - 1; overhead > 0.5 {
+		t.Fatalf("expected overhead < 50%%, found %.2f%%\n", overhead*100)
+	}
+
+	// elimination is tried as soon as peeling stalls, so decoding
+	// completes as soon as received pieces are of full rank
+	dec := decoderFlow(t, replay(), fountain.NewLTDecoder(pieceCount), pieces)
+	if peeled, eliminated := dec.Stats(); peeled+eliminated != pieceCount {
+		t.Fatalf("expected %d pieces decoded, found %d peeled & %d eliminated\n", pieceCount, peeled, eliminated)
+	}
+	if dec.Received() > delayed.Received() {
+		t.Fatalf("expected at most %d pieces received, found %d\n", delayed.Received(), dec.Received())
+	}
+}
+
+// With small generations, peeling stalls often, where Gaussian
+// elimination must take over, as soon as enough pieces are received
+func TestLTDecoderFallback(t *testing.T) {
+	var (
+		pieceCount  uint = 16
+		pieceLength uint = 64
+		eliminated  uint = 0
+	)
+
+	for range 32 {
+		pieces := generatePieces(pieceCount, pieceLength)
+		dist, err := fountain.NewRobustSoliton(pieceCount, 0.1, 0.5)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		_, e := decoderFlow(t, fountain.NewLTEncoder(pieces, dist).CodedPiece, fountain.NewLTDecoder(pieceCount), pieces).Stats()
+		eliminated += e
+	}
+
+	if eliminated == 0 {
+		t.Fatal("expected Gaussian elimination to be used at least once")
+	}
+}
+
+func TestLTDecoderPieceLengthMismatch(t *testing.T) {
+	var (
+		pieceCount  uint = 16
+		pieceLength uint = 64
+	)
+
+	dist, err := fountain.NewRobustSoliton(pieceCount, 0.1, 0.5)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	enc := fountain.NewLTEncoder(generatePieces(pieceCount, pieceLength), dist)
+	dec := fountain.NewLTDecoder(pieceCount)
+	if err := dec.AddPiece(enc.CodedPiece()); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	piece := enc.CodedPiece()
+	piece.Piece = piece.Piece[:pieceLength-1]
+	if err := dec.AddPiece(piece); !errors.Is(err, kodr.ErrCodedDataLengthMismatch) {
+		t.Fatalf("expected: %s\n", kodr.ErrCodedDataLengthMismatch)
+	}
+	if err := dec.AddConstraint(make(kodr_internals.CodingVector, pieceCount), pieceLength+1); !errors.Is(err, kodr.ErrCodedDataLengthMismatch) {
+		t.Fatalf("expected: %s\n", kodr.ErrCodedDataLengthMismatch)
+	}
+	if dec.Received() != 1 {
+		t.Fatalf("expected 1 piece received, found %d\n", dec.Received())
+	}
+}
+
+// LT coded pieces stay in same vector model, so full RLNC
+// decoder can consume them too
+func TestLTWithFullRLNCDecoder(t *testing.T) {
+	var (
+		pieceCount  uint = 64
+		pieceLength uint = 64
+		pieces           = generatePieces(pieceCount, pieceLength)
+	)
+
+	dist, err := fountain.NewRobustSoliton(pieceCount, 0.1, 0.5)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	enc := fountain.NewLTEncoder(pieces, dist)
+	dec := full.NewFullRLNCDecoder(pieceCount)
+	for !dec.IsDecoded() {
+		dec.AddPiece(enc.CodedPiece())
+	}
+
+	d_pieces, err := dec.GetPieces()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	for i := range pieces {
+		if !bytes.Equal(pieces[i], d_pieces[i]) {
+			t.Fatal("decoded data doesn't match !")
+		}
+	}
+}
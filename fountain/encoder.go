@@ -0,0 +1,90 @@
+package fountain
+
+import (
+	"math/rand"
+
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf2"
+)
+
+type LTEncoder struct {
+	pieces       []kodr_internals.Piece
+	distribution DegreeDistribution
+	extra        uint
+}
+
+// Total #-of pieces being coded together
+func (l *LTEncoder) PieceCount() uint {
+	return uint(len(l.pieces))
+}
+
+// Pieces which are coded together are all of same size
+func (l *LTEncoder) PieceSize() uint {
+	return uint(len(l.pieces[0]))
+}
+
+// If N-many original pieces are coded together
+// what could be length of one such coded piece
+// obtained by invoking `CodedPiece` ?
+func (l *LTEncoder) CodedPieceLen() uint {
+	return l.PieceCount() + l.PieceSize()
+}
+
+// How many extra padding bytes added at end of
+// original data slice so that splitted pieces are
+// all of same size ?
+func (l *LTEncoder) Padding() uint {
+	return l.extra
+}
+
+// Returns a coded piece, which is XOR of `d` distinct original pieces,
+// chosen uniformly at random, where `d` is drawn from degree distribution
+func (l *LTEncoder) CodedPiece() *kodr_internals.CodedPiece {
+	pieceCount := l.PieceCount()
+	degree := min(max(l.distribution.Degree(), 1), pieceCount)
+
+	vector := make(kodr_internals.CodingVector, pieceCount)
+	piece := make(kodr_internals.Piece, l.PieceSize())
+
+	// Floyd's algorithm, for choosing `degree` distinct indices
+	for j := pieceCount - degree; j < pieceCount; j++ {
+		i := uint(rand.Intn(int(j + 1)))
+		if vector[i] == 1 {
+			i = j
+		}
+
+		vector[i] = 1
+		gf2.Xor(piece, l.pieces[i])
+	}
+
+	return &kodr_internals.CodedPiece{
+		Vector: vector,
+		Piece:  piece,
+	}
+}
+
+// Provide with original pieces & degree distribution ( say robust
+// soliton, built for same #-of pieces ) to get LT encoder
+func NewLTEncoder(pieces []kodr_internals.Piece, distribution DegreeDistribution) *LTEncoder {
+	return &LTEncoder{pieces: pieces, distribution: distribution}
+}
+
+// If you know #-of pieces you want to code together, invoking
+// this function splits whole data chunk into N-pieces, with padding
+// bytes appended at end of last piece, if required & prepares LT
+// encoder, with robust soliton distribution of given parameters
+func NewLTEncoderWithPieceCount(data []byte, pieceCount uint, c, delta float64) (*LTEncoder, error) {
+	pieces, padding, err := kodr_internals.OriginalPiecesFromDataAndPieceCount(data, pieceCount)
+	if err != nil {
+		return nil, err
+	}
+
+	distribution, err := NewRobustSoliton(pieceCount, c, delta)
+	if err != nil {
+		return nil, err
+	}
+
+	enc := NewLTEncoder(pieces, distribution)
+	enc.extra = padding
+	return enc, nil
+}
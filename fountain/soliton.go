@@ -0,0 +1,88 @@
+// LT ( Luby Transform ) fountain codes, where each coded piece is sum of
+// a few randomly chosen original pieces, #-of which ( read degree ) follows
+// robust soliton distribution, so that decoder can mostly peel i.e. keep
+// resolving coded pieces having only one unknown original piece, which
+// makes decoding near-linear for very large generations
+//
+// Coded pieces are same `kodr_internals.CodedPiece`, where coding vector
+// holds N coefficients, each being 0 or 1, so that they can also be
+// consumed by any other decoder of this library
+package fountain
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/itzmeanjan/kodr"
+)
+
+// Draws degree of next coded piece i.e. #-of original pieces
+// to be combined together, which must be in [1, N]
+type DegreeDistribution interface {
+	Degree() uint
+}
+
+// Robust soliton distribution over [1, K], as proposed by Luby
+type RobustSoliton struct {
+	cdf []float64
+}
+
+// Builds robust soliton distribution for K pieces, where `c` is a
+// ( small ) positive constant & `delta` is allowed failure probability
+// of decoding, after receiving K + O(sqrt(K) * ln^2(K/delta)) pieces
+func NewRobustSoliton(k uint, c, delta float64) (*RobustSoliton, error) {
+	if k == 0 || !(c > 0) || !(delta > 0 && delta < 1) {
+		return nil, kodr.ErrBadDistributionParameters
+	}
+
+	var (
+		k_ = float64(k)
+		r  = c * math.Log(k_/delta) * math.Sqrt(k_)
+		// degree, where spike of robust component lands
+		spike = min(k, max(1, uint(math.Round(k_/r))))
+	)
+
+	pmf := make([]float64, k+1)
+	for d := uint(1); d <= k; d++ {
+		// ideal soliton
+		if d == 1 {
+			pmf[d] = 1 / k_
+		} else {
+			pmf[d] = 1 / float64(d*(d-1))
+		}
+
+		// robust component
+		switch {
+		case d < spike:
+			pmf[d] += r / (float64(d) * k_)
+		case d == spike:
+			pmf[d] += r * math.Log(r/delta) / k_
+		}
+	}
+
+	cdf := make([]float64, k+1)
+	for d := uint(1); d <= k; d++ {
+		cdf[d] = cdf[d-1] + pmf[d]
+	}
+	for d := range cdf {
+		cdf[d] /= cdf[k]
+	}
+
+	return &RobustSoliton{cdf: cdf}, nil
+}
+
+// Probability of drawing degree `d`
+func (r *RobustSoliton) Probability(d uint) float64 {
+	if d == 0 || d >= uint(len(r.cdf)) {
+		return 0
+	}
+	return r.cdf[d] - r.cdf[d-1]
+}
+
+// Draws a degree in [1, K]
+func (r *RobustSoliton) Degree() uint {
+	u := rand.Float64()
+	d := sort.SearchFloat64s(r.cdf, u)
+	return uint(max(1, min(d, len(r.cdf)-1)))
+}
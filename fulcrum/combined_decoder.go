@@ -0,0 +1,228 @@
+package fulcrum
+
+import (
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf2"
+	"github.com/itzmeanjan/kodr/kodr_internals/matrix"
+)
+
+// Decodes mostly in GF(2), eliminating received pieces by XOR-ing, as
+// inner decoder does. But as soon as rank of GF(2) system reaches N,
+// outer code is used for solving remaining unknowns in GF(2^8), so that
+// decoding completes without waiting for N + r linearly independent
+// pieces ( over GF(2) ), as outer decoder does, though at much lower cost
+type FulcrumCombinedDecoder struct {
+	expansion *expansion
+	received  uint
+	state     *gf2.DecoderState
+	decoded   []kodr_internals.Piece
+}
+
+// PieceLength - Returns piece length in bytes
+//
+// If no pieces are yet added to decoder state, then
+// returns 0, denoting **unknown**
+func (d *FulcrumCombinedDecoder) PieceLength() uint {
+	return d.state.PieceLength()
+}
+
+// IsDecoded - Use it for checking whether more piece
+// collection is required or not
+func (d *FulcrumCombinedDecoder) IsDecoded() bool {
+	return d.decoded != nil
+}
+
+// Required - At least how many more coded pieces are required
+// for successfully decoding pieces ?
+//
+// Note: Once rank of GF(2) system reaches N, it can't be told how
+// many more are required, before trying to decode, which is why
+// returned value is only a lower bound
+func (d *FulcrumCombinedDecoder) Required() uint {
+	if d.IsDecoded() {
+		return 0
+	}
+	if rank := d.state.Rank(); rank < d.expansion.pieceCount() {
+		return d.expansion.pieceCount() - rank
+	}
+	return 1
+}
+
+// Received - How many coded pieces are received so far, including
+// linearly dependent ones
+func (d *FulcrumCombinedDecoder) Received() uint {
+	return d.received
+}
+
+// AddPiece - Adds a new received coded piece, which is eliminated
+// right away in GF(2). If it's useful & rank is >= N, decoding is
+// attempted in GF(2^8)
+func (d *FulcrumCombinedDecoder) AddPiece(piece *kodr_internals.CodedPiece) error {
+	if d.IsDecoded() {
+		return kodr.ErrAllUsefulPiecesReceived
+	}
+
+	useful, err := d.state.AddPiece(piece.Vector, piece.Piece)
+	if err != nil {
+		return err
+	}
+	d.received++
+	if !useful {
+		return nil
+	}
+	if d.state.Rank() < d.expansion.pieceCount() {
+		return nil
+	}
+
+	d.decode()
+	return nil
+}
+
+// Reduced GF(2) basis has one row per pivot column p, denoting
+//
+//	y_p + sum of y_f, over free columns f set in row = c_p
+//
+// where y_j is j-th expanded piece. So only free ( non-pivot ) columns,
+// at most r of them, are unknown. Each expansion piece j satisfies outer
+// code's constraint
+//
+//	y_j + sum of G[j][k] * y_k, over k < N = 0
+//
+// which, after substituting pivot columns, is an equation over free
+// columns only. Solving those r equations in GF(2^8) reveals free columns,
+// then all original pieces are recovered by XOR-ing, which is much cheaper
+// than solving all N pieces in GF(2^8)
+func (d *FulcrumCombinedDecoder) decode() {
+	var (
+		pieceCount    = d.expansion.pieceCount()
+		expandedCount = d.expansion.expandedCount()
+		rank          = d.state.Rank()
+		f             = field.Default()
+	)
+
+	rows := make([][]byte, rank)
+	pivotOf := make([]int, expandedCount)
+	for i := range pivotOf {
+		pivotOf[i] = -1
+	}
+	for i := range rank {
+		rows[i], _ = d.state.Row(i)
+		pivotOf[d.state.Pivot(i)] = int(i)
+	}
+
+	free := make([]uint, 0, expandedCount-rank)
+	for j := range expandedCount {
+		if pivotOf[j] < 0 {
+			free = append(free, j)
+		}
+	}
+
+	solved := make([]kodr_internals.Piece, len(free))
+	if len(free) > 0 {
+		state := matrix.NewDecoderStateWithPieceCount(uint(len(free)))
+		for j := pieceCount; j < expandedCount; j++ {
+			// constraint row over all expanded pieces, in GF(2^8)
+			constraint := make([]byte, expandedCount)
+			row, _ := d.expansion.generator.Row(j)
+			copy(constraint, row)
+			constraint[j] = 1
+
+			vector := make(kodr_internals.CodingVector, len(free))
+			piece := make(kodr_internals.Piece, d.state.PieceLength())
+			for k := range free {
+				vector[k] = constraint[free[k]]
+			}
+			for i := range rank {
+				a := uint32(constraint[d.state.Pivot(i)])
+				if a == 0 {
+					continue
+				}
+
+				_, coded := d.state.Row(i)
+				f.MulAddSlice(piece, coded, a)
+				for k := range free {
+					if gf2.Get(rows[i], free[k]) {
+						vector[k] ^= byte(a)
+					}
+				}
+			}
+
+			state.AddPiece(&kodr_internals.CodedPiece{Vector: vector, Piece: piece})
+		}
+
+		state.Rref()
+		if state.Rank() < uint(len(free)) {
+			return
+		}
+
+		for k := range free {
+			// rank is full, so all free columns are solved
+			solved[k], _ = state.GetPiece(uint(k))
+		}
+	}
+
+	decoded := make([]kodr_internals.Piece, pieceCount)
+	for k := range free {
+		if free[k] < pieceCount {
+			decoded[free[k]] = solved[k]
+		}
+	}
+	for p := range pieceCount {
+		i := pivotOf[p]
+		if i < 0 {
+			continue
+		}
+
+		_, coded := d.state.Row(uint(i))
+		piece := make(kodr_internals.Piece, len(coded))
+		copy(piece, coded)
+		for k := range free {
+			if gf2.Get(rows[i], free[k]) {
+				gf2.Xor(piece, solved[k])
+			}
+		}
+		decoded[p] = piece
+	}
+
+	d.decoded = decoded
+}
+
+// GetPiece - Get a decoded piece by index, may ( not ) succeed !
+//
+// Note: Before full decoding, original piece is available as soon as
+// it's revealed by GF(2) elimination
+func (d *FulcrumCombinedDecoder) GetPiece(i uint) (kodr_internals.Piece, error) {
+	if i >= d.expansion.pieceCount() {
+		return nil, kodr.ErrPieceOutOfBound
+	}
+	if d.IsDecoded() {
+		return d.decoded[i], nil
+	}
+	return d.state.GetPiece(i)
+}
+
+// GetPieces - Get a list of all decoded pieces, given full
+// decoding has happened
+func (d *FulcrumCombinedDecoder) GetPieces() ([]kodr_internals.Piece, error) {
+	if !d.IsDecoded() {
+		return nil, kodr.ErrMoreUsefulPiecesRequired
+	}
+
+	return d.decoded, nil
+}
+
+// Decoder for pieces coded by Fulcrum encoder ( or recoded by binary
+// recoder ), where N original pieces are expanded with r expansion pieces
+func NewFulcrumCombinedDecoder(pieceCount, expansionCount uint) (*FulcrumCombinedDecoder, error) {
+	exp, err := newExpansion(pieceCount, expansionCount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FulcrumCombinedDecoder{
+		expansion: exp,
+		state:     gf2.NewDecoderState(pieceCount + expansionCount),
+	}, nil
+}
@@ -0,0 +1,195 @@
+package fulcrum_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/binary"
+	"github.com/itzmeanjan/kodr/fulcrum"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+)
+
+type decoder interface {
+	IsDecoded() bool
+	Received() uint
+	AddPiece(*kodr_internals.CodedPiece) error
+	GetPieces() ([]kodr_internals.Piece, error)
+}
+
+// Keeps feeding coded pieces to decoder until it's able to decode,
+// then compares decoded pieces with original ones & returns #-of
+// coded pieces it took
+func decoderFlow(t *testing.T, dec decoder, next func() *kodr_internals.CodedPiece, pieces []kodr_internals.Piece) uint {
+	for {
+		if err := dec.AddPiece(next()); errors.Is(err, kodr.ErrAllUsefulPiecesReceived) {
+			break
+		}
+	}
+
+	d_pieces, err := dec.GetPieces()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if len(pieces) != len(d_pieces) {
+		t.Fatal("didn't decode all !")
+	}
+
+	for i := range pieces {
+		if !bytes.Equal(pieces[i], d_pieces[i]) {
+			t.Fatal("decoded data doesn't match !")
+		}
+	}
+
+	return dec.Received()
+}
+
+func newDecoders(t *testing.T, pieceCount, expansionCount uint) map[string]decoder {
+	outer, err := fulcrum.NewFulcrumOuterDecoder(pieceCount, expansionCount)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	combined, err := fulcrum.NewFulcrumCombinedDecoder(pieceCount, expansionCount)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	return map[string]decoder{
+		"Outer":    outer,
+		"Inner":    fulcrum.NewFulcrumInnerDecoder(pieceCount, expansionCount),
+		"Combined": combined,
+	}
+}
+
+func TestFulcrumDecoders(t *testing.T) {
+	var (
+		pieceCount     uint = 64
+		expansionCount uint = 8
+		pieceLength    uint = 1024
+		pieces              = generatePieces(pieceCount, pieceLength)
+	)
+
+	enc, err := fulcrum.NewFulcrumEncoder(pieces, expansionCount)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	for name, dec := range newDecoders(t, pieceCount, expansionCount) {
+		t.Run(name, func(t *testing.T) {
+			decoderFlow(t, dec, enc.CodedPiece, pieces)
+		})
+	}
+
+	// relay recodes in GF(2), without knowing anything about outer code
+	t.Run("Recoded", func(t *testing.T) {
+		coded := make([]*kodr_internals.CodedPiece, 0, 2*(pieceCount+expansionCount))
+		for range 2 * (pieceCount + expansionCount) {
+			coded = append(coded, enc.CodedPiece())
+		}
+
+		rec := binary.NewBinaryRLNCRecoder(coded)
+		for name, dec := range newDecoders(t, pieceCount, expansionCount) {
+			t.Run(name, func(t *testing.T) {
+				decoderFlow(t, dec, rec.CodedPiece, pieces)
+			})
+		}
+	})
+}
+
+// Decoding in GF(2^8) must require fewer coded pieces on average,
+// than decoding all N + r expanded pieces in GF(2)
+func TestFulcrumDecodingOverhead(t *testing.T) {
+	var (
+		pieceCount     uint = 32
+		expansionCount uint = 8
+		pieceLength    uint = 64
+		rounds              = 16
+		received            = make(map[string]uint)
+	)
+
+	for range rounds {
+		pieces := generatePieces(pieceCount, pieceLength)
+		enc, err := fulcrum.NewFulcrumEncoder(pieces, expansionCount)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		coded := make([]*kodr_internals.CodedPiece, 0)
+		next := func(i *int) func() *kodr_internals.CodedPiece {
+			return func() *kodr_internals.CodedPiece {
+				for *i >= len(coded) {
+					coded = append(coded, enc.CodedPiece())
+				}
+				*i++
+				return coded[*i-1]
+			}
+		}
+
+		// all decoders are fed with same sequence of coded pieces
+		for name, dec := range newDecoders(t, pieceCount, expansionCount) {
+			i := 0
+			received[name] += decoderFlow(t, dec, next(&i), pieces)
+		}
+	}
+
+	if received["Outer"] > received["Combined"] {
+		t.Fatalf("outer decoder took %d pieces, more than combined decoder, which took %d\n", received["Outer"], received["Combined"])
+	}
+	if received["Combined"] >= received["Inner"] {
+		t.Fatalf("combined decoder took %d pieces, inner decoder took %d\n", received["Combined"], received["Inner"])
+	}
+	if avg := float64(received["Combined"]) / float64(rounds); avg > float64(pieceCount+expansionCount) {
+		t.Fatalf("combined decoder took %.2f pieces on average, expected < %d\n", avg, pieceCount+expansionCount)
+	}
+}
+
+func TestFulcrumDecoderPieceOutOfBound(t *testing.T) {
+	dec, err := fulcrum.NewFulcrumCombinedDecoder(16, 4)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if _, err := dec.GetPiece(16); !errors.Is(err, kodr.ErrPieceOutOfBound) {
+		t.Fatalf("expected: %s\n", kodr.ErrPieceOutOfBound)
+	}
+	if _, err := fulcrum.NewFulcrumOuterDecoder(250, 8); !errors.Is(err, kodr.ErrTooManyPiecesForGenerator) {
+		t.Fatalf("expected: %s\n", kodr.ErrTooManyPiecesForGenerator)
+	}
+}
+
+func TestFulcrumDecoderLengthMismatch(t *testing.T) {
+	var (
+		pieceCount     uint = 16
+		expansionCount uint = 4
+		pieceLength    uint = 64
+	)
+
+	enc, err := fulcrum.NewFulcrumEncoder(generatePieces(pieceCount, pieceLength), expansionCount)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	for name, dec := range newDecoders(t, pieceCount, expansionCount) {
+		t.Run(name, func(t *testing.T) {
+			piece := enc.CodedPiece()
+			short := &kodr_internals.CodedPiece{Vector: piece.Vector[:len(piece.Vector)-1], Piece: piece.Piece}
+			if err := dec.AddPiece(short); !errors.Is(err, kodr.ErrCodingVectorLengthMismatch) {
+				t.Fatalf("expected: %s\n", kodr.ErrCodingVectorLengthMismatch)
+			}
+			if err := dec.AddPiece(piece); err != nil {
+				t.Fatal(err.Error())
+			}
+
+			short = &kodr_internals.CodedPiece{Vector: enc.CodedPiece().Vector, Piece: make(kodr_internals.Piece, pieceLength-1)}
+			if err := dec.AddPiece(short); !errors.Is(err, kodr.ErrCodedDataLengthMismatch) {
+				t.Fatalf("expected: %s\n", kodr.ErrCodedDataLengthMismatch)
+			}
+			if dec.Received() != 1 {
+				t.Fatalf("expected 1 piece received, found %d\n", dec.Received())
+			}
+		})
+	}
+}
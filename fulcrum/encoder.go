@@ -0,0 +1,105 @@
+package fulcrum
+
+import (
+	"github.com/itzmeanjan/kodr/binary"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf2"
+)
+
+type FulcrumEncoder struct {
+	expansion *expansion
+	inner     *binary.BinaryRLNCEncoder
+	extra     uint
+}
+
+// Total #-of original pieces being coded together
+func (f *FulcrumEncoder) PieceCount() uint {
+	return f.expansion.pieceCount()
+}
+
+// #-of expansion pieces, appended by outer code, which is
+// why coding vector of each coded piece is of N + r bits
+func (f *FulcrumEncoder) ExpansionCount() uint {
+	return f.expansion.expandedCount() - f.expansion.pieceCount()
+}
+
+// Pieces which are coded together are all of same size
+func (f *FulcrumEncoder) PieceSize() uint {
+	return f.inner.PieceSize()
+}
+
+// If N-many original pieces are expanded to N + r pieces & coded
+// together, what could be length of one such coded piece ?
+//
+// Coding vector is bit-packed, so it takes ceil((N+r)/8) bytes
+func (f *FulcrumEncoder) CodedPieceLen() uint {
+	return gf2.VectorLen(f.expansion.expandedCount()) + f.PieceSize()
+}
+
+// How many extra padding bytes added at end of
+// original data slice so that splitted pieces are
+// all of same size ?
+func (f *FulcrumEncoder) Padding() uint {
+	return f.extra
+}
+
+// Returns a coded piece, which is constructed on-the-fly by randomly
+// selecting a subset of N + r expanded pieces & XOR-ing them together
+func (f *FulcrumEncoder) CodedPiece() *kodr_internals.CodedPiece {
+	return f.inner.CodedPiece()
+}
+
+// Provide with original pieces & #-of expansion pieces, outer code
+// appends, to get encoder, producing coded pieces on-the-fly
+//
+// Note: len(pieces) + expansionCount must be <= 256
+func NewFulcrumEncoder(pieces []kodr_internals.Piece, expansionCount uint) (*FulcrumEncoder, error) {
+	exp, err := newExpansion(uint(len(pieces)), expansionCount)
+	if err != nil {
+		return nil, err
+	}
+
+	expanded, err := exp.expand(pieces)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FulcrumEncoder{expansion: exp, inner: binary.NewBinaryRLNCEncoder(expanded)}, nil
+}
+
+// If you know #-of pieces you want to code together, invoking
+// this function splits whole data chunk into N-pieces, with padding
+// bytes appended at end of last piece, if required & prepares
+// Fulcrum encoder
+func NewFulcrumEncoderWithPieceCount(data []byte, pieceCount uint, expansionCount uint) (*FulcrumEncoder, error) {
+	pieces, padding, err := kodr_internals.OriginalPiecesFromDataAndPieceCount(data, pieceCount)
+	if err != nil {
+		return nil, err
+	}
+
+	enc, err := NewFulcrumEncoder(pieces, expansionCount)
+	if err != nil {
+		return nil, err
+	}
+
+	enc.extra = padding
+	return enc, nil
+}
+
+// If you want to have N-bytes piece size for each, this
+// function generates M-many pieces each of N-bytes size & prepares
+// Fulcrum encoder
+func NewFulcrumEncoderWithPieceSize(data []byte, pieceSize uint, expansionCount uint) (*FulcrumEncoder, error) {
+	pieces, padding, err := kodr_internals.OriginalPiecesFromDataAndPieceSize(data, pieceSize)
+	if err != nil {
+		return nil, err
+	}
+
+	enc, err := NewFulcrumEncoder(pieces, expansionCount)
+	if err != nil {
+		return nil, err
+	}
+
+	enc.extra = padding
+	return enc, nil
+}
@@ -0,0 +1,77 @@
+package fulcrum_test
+
+import (
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/fulcrum"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf2"
+)
+
+// Generates `N`-bytes of random data from default
+// randomization source
+func generateData(n uint) []byte {
+	data := make([]byte, n)
+	rand.Read(data)
+	return data
+}
+
+// Generates N-many pieces each of M-bytes length, to be used
+// for testing purposes
+func generatePieces(pieceCount uint, pieceLength uint) []kodr_internals.Piece {
+	pieces := make([]kodr_internals.Piece, 0, pieceCount)
+	for range pieceCount {
+		pieces = append(pieces, generateData(pieceLength))
+	}
+	return pieces
+}
+
+func TestNewFulcrumEncoder(t *testing.T) {
+	var (
+		pieceCount     uint = 64
+		expansionCount uint = 4
+		pieceLength    uint = 1024
+		pieces              = generatePieces(pieceCount, pieceLength)
+	)
+
+	if _, err := fulcrum.NewFulcrumEncoder(generatePieces(250, 8), 8); !errors.Is(err, kodr.ErrTooManyPiecesForGenerator) {
+		t.Fatalf("expected: %s\n", kodr.ErrTooManyPiecesForGenerator)
+	}
+
+	enc, err := fulcrum.NewFulcrumEncoder(pieces, expansionCount)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if enc.ExpansionCount() != expansionCount {
+		t.Fatalf("expected %d expansion pieces, found %d\n", expansionCount, enc.ExpansionCount())
+	}
+
+	expected := gf2.VectorLen(pieceCount+expansionCount) + pieceLength
+	if c_piece := enc.CodedPiece(); enc.CodedPieceLen() != expected || c_piece.Len() != expected {
+		t.Fatalf("expected coded piece length %dB, found %dB\n", expected, c_piece.Len())
+	}
+}
+
+func TestNewFulcrumEncoderWithPieceCount(t *testing.T) {
+	var (
+		pieceCount     uint = 32
+		expansionCount uint = 2
+		data                = generateData(10_001)
+	)
+
+	enc, err := fulcrum.NewFulcrumEncoderWithPieceCount(data, pieceCount, expansionCount)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if enc.PieceCount() != pieceCount {
+		t.Fatalf("expected %d pieces, found %d\n", pieceCount, enc.PieceCount())
+	}
+	if enc.PieceCount()*enc.PieceSize() != uint(len(data))+enc.Padding() {
+		t.Fatal("pieces must hold whole data, along with padding")
+	}
+}
@@ -0,0 +1,87 @@
+// Fulcrum codes, where N original pieces are first expanded using a
+// systematic outer code over GF(2^8), by appending r expansion pieces,
+// then those N + r pieces are coded together using binary RLNC i.e. the
+// inner code over GF(2)
+//
+// Encoders & relays only XOR pieces, while end hosts can choose to decode
+// either in GF(2) ( cheap, but requires >= N + r pieces ), or in GF(2^8)
+// ( requires a bit more than N pieces ) or combining both
+//
+// Relays recode using `binary.BinaryRLNCRecoder`, because coded pieces
+// are nothing but binary RLNC coded pieces over N + r expanded pieces
+package fulcrum
+
+import (
+	"github.com/itzmeanjan/kodr/erasure"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf2"
+)
+
+// Outer code, both encoder & decoders agree upon, which maps
+// N original pieces to N + r expanded pieces
+type expansion struct {
+	generator *erasure.Generator
+}
+
+// Systematic outer code, where expansion pieces are combined using
+// Cauchy rows, so that any N of expanded pieces are enough for
+// reconstructing original pieces
+//
+// Note: pieceCount + expansionCount must be <= 256
+func newExpansion(pieceCount, expansionCount uint) (*expansion, error) {
+	generator, err := erasure.NewSystematicGenerator(pieceCount, expansionCount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &expansion{generator: generator}, nil
+}
+
+func (e *expansion) pieceCount() uint {
+	return e.generator.PieceCount()
+}
+
+func (e *expansion) expandedCount() uint {
+	return e.generator.CodedPieceCount()
+}
+
+// Expands original pieces to N + r pieces, where first N
+// are original pieces themselves
+func (e *expansion) expand(pieces []kodr_internals.Piece) ([]kodr_internals.Piece, error) {
+	enc, err := erasure.NewErasureEncoder(pieces, e.generator)
+	if err != nil {
+		return nil, err
+	}
+
+	expanded := make([]kodr_internals.Piece, 0, e.expandedCount())
+	expanded = append(expanded, pieces...)
+	for i := e.pieceCount(); i < e.expandedCount(); i++ {
+		c_piece, err := enc.CodedPiece(i)
+		if err != nil {
+			return nil, err
+		}
+		expanded = append(expanded, c_piece.Piece)
+	}
+
+	return expanded, nil
+}
+
+// Maps bit-packed inner coding vector over N + r expanded pieces to
+// outer coding vector over N original pieces i.e. GF(2^8) coefficients
+//
+// Inner coded piece is XOR of selected expanded pieces, while addition
+// in GF(2^8) is XOR too, so outer coding vector is XOR of generator rows
+// of selected expanded pieces
+func (e *expansion) outerVector(vector []byte) kodr_internals.CodingVector {
+	outer := make(kodr_internals.CodingVector, e.pieceCount())
+	for i := range e.expandedCount() {
+		if !gf2.Get(vector, i) {
+			continue
+		}
+
+		// index is always within bound
+		row, _ := e.generator.Row(i)
+		gf2.Xor(outer, row)
+	}
+	return outer
+}
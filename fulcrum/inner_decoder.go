@@ -0,0 +1,90 @@
+package fulcrum
+
+import (
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf2"
+)
+
+// Decodes in GF(2) only, treating N + r expanded pieces as if they were
+// original pieces, so that only XOR-ing is required, though at least
+// N + r linearly independent coded pieces need to be received
+type FulcrumInnerDecoder struct {
+	pieceCount, received uint
+	state                *gf2.DecoderState
+}
+
+// PieceLength - Returns piece length in bytes
+//
+// If no pieces are yet added to decoder state, then
+// returns 0, denoting **unknown**
+func (d *FulcrumInnerDecoder) PieceLength() uint {
+	return d.state.PieceLength()
+}
+
+// IsDecoded - Use it for checking whether more piece
+// collection is required or not
+func (d *FulcrumInnerDecoder) IsDecoded() bool {
+	return d.Required() == 0
+}
+
+// Required - How many more linearly independent pieces
+// are required for successfully decoding all expanded pieces ?
+func (d *FulcrumInnerDecoder) Required() uint {
+	return d.state.Expected() - d.state.Rank()
+}
+
+// Received - How many coded pieces are received so far, including
+// linearly dependent ones
+func (d *FulcrumInnerDecoder) Received() uint {
+	return d.received
+}
+
+// AddPiece - Adds a new received coded piece, which is eliminated
+// right away using already received pieces, by XOR-ing
+func (d *FulcrumInnerDecoder) AddPiece(piece *kodr_internals.CodedPiece) error {
+	if d.IsDecoded() {
+		return kodr.ErrAllUsefulPiecesReceived
+	}
+
+	if _, err := d.state.AddPiece(piece.Vector, piece.Piece); err != nil {
+		return err
+	}
+	d.received++
+	return nil
+}
+
+// GetPiece - Get a decoded original piece by index, may ( not ) succeed !
+func (d *FulcrumInnerDecoder) GetPiece(i uint) (kodr_internals.Piece, error) {
+	if i >= d.pieceCount {
+		return nil, kodr.ErrPieceOutOfBound
+	}
+	return d.state.GetPiece(i)
+}
+
+// GetPieces - Get a list of all decoded original pieces, given full
+// decoding has happened
+func (d *FulcrumInnerDecoder) GetPieces() ([]kodr_internals.Piece, error) {
+	if !d.IsDecoded() {
+		return nil, kodr.ErrMoreUsefulPiecesRequired
+	}
+
+	pieces := make([]kodr_internals.Piece, 0, d.pieceCount)
+	for i := range d.pieceCount {
+		piece, err := d.GetPiece(i)
+		if err != nil {
+			return nil, err
+		}
+		pieces = append(pieces, piece)
+	}
+	return pieces, nil
+}
+
+// Decoder for pieces coded by Fulcrum encoder ( or recoded by binary
+// recoder ), where N original pieces are expanded with r expansion pieces
+func NewFulcrumInnerDecoder(pieceCount, expansionCount uint) *FulcrumInnerDecoder {
+	return &FulcrumInnerDecoder{
+		pieceCount: pieceCount,
+		state:      gf2.NewDecoderState(pieceCount + expansionCount),
+	}
+}
@@ -0,0 +1,97 @@
+package fulcrum
+
+import (
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/full"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf2"
+)
+
+// Decodes in GF(2^8), by mapping each received inner coding vector
+// to outer coding vector over N original pieces, so that only a
+// little more than N coded pieces are required, same as full RLNC
+// over GF(2^8), though at cost of GF(2^8) arithmetic
+type FulcrumOuterDecoder struct {
+	expansion *expansion
+	decoder   *full.FullRLNCDecoder
+	received  uint
+}
+
+// PieceLength - Returns piece length in bytes
+//
+// If no pieces are yet added to decoder state, then
+// returns 0, denoting **unknown**
+func (d *FulcrumOuterDecoder) PieceLength() uint {
+	return d.decoder.PieceLength()
+}
+
+// IsDecoded - Use it for checking whether more piece
+// collection is required or not
+func (d *FulcrumOuterDecoder) IsDecoded() bool {
+	return d.decoder.IsDecoded()
+}
+
+// Required - How many more linearly independent pieces
+// are required for successfully decoding pieces ?
+func (d *FulcrumOuterDecoder) Required() uint {
+	return d.decoder.Required()
+}
+
+// Received - How many coded pieces are received so far, including
+// linearly dependent ones
+func (d *FulcrumOuterDecoder) Received() uint {
+	return d.received
+}
+
+// AddPiece - Adds a new received coded piece, whose coding vector
+// is first mapped to GF(2^8) coefficients over original pieces
+//
+// Note: Coded piece is copied, caller's slices are never modified
+func (d *FulcrumOuterDecoder) AddPiece(piece *kodr_internals.CodedPiece) error {
+	if d.IsDecoded() {
+		return kodr.ErrAllUsefulPiecesReceived
+	}
+
+	// coding vector is over all N + r expanded pieces, one bit each
+	expandedCount := d.expansion.expandedCount()
+	if uint(len(piece.Vector)) != gf2.VectorLen(expandedCount) {
+		return kodr.ErrCodingVectorLengthMismatch
+	}
+	if tail := expandedCount % 8; tail != 0 && piece.Vector[len(piece.Vector)-1]>>tail != 0 {
+		return kodr.ErrCodingVectorPaddingNotZero
+	}
+
+	coded := make(kodr_internals.Piece, len(piece.Piece))
+	copy(coded, piece.Piece)
+
+	if err := d.decoder.AddPiece(&kodr_internals.CodedPiece{
+		Vector: d.expansion.outerVector(piece.Vector),
+		Piece:  coded,
+	}); err != nil {
+		return err
+	}
+	d.received++
+	return nil
+}
+
+// GetPiece - Get a decoded piece by index, may ( not ) succeed !
+func (d *FulcrumOuterDecoder) GetPiece(i uint) (kodr_internals.Piece, error) {
+	return d.decoder.GetPiece(i)
+}
+
+// GetPieces - Get a list of all decoded pieces, given full
+// decoding has happened
+func (d *FulcrumOuterDecoder) GetPieces() ([]kodr_internals.Piece, error) {
+	return d.decoder.GetPieces()
+}
+
+// Decoder for pieces coded by Fulcrum encoder ( or recoded by binary
+// recoder ), where N original pieces are expanded with r expansion pieces
+func NewFulcrumOuterDecoder(pieceCount, expansionCount uint) (*FulcrumOuterDecoder, error) {
+	exp, err := newExpansion(pieceCount, expansionCount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FulcrumOuterDecoder{expansion: exp, decoder: full.NewFullRLNCDecoder(pieceCount)}, nil
+}
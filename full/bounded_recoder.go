@@ -0,0 +1,205 @@
+package full
+
+import (
+	"math/rand"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf65536"
+	"github.com/itzmeanjan/kodr/kodr_internals/matrix"
+)
+
+// Decides which buffered pieces a newly received piece is folded into,
+// once buffer is full, given how many pieces were received before it
+//
+// Returned indices are expected to be distinct & in [0, capacity)
+type FoldPolicy interface {
+	Targets(received, capacity uint) []uint
+}
+
+// Folds new piece into every buffered piece, so that buffer always holds
+// random linear combinations of everything received, which retains most
+// information, though each fold costs `capacity` many row operations
+type FoldIntoAll struct{}
+
+func (FoldIntoAll) Targets(received, capacity uint) []uint {
+	targets := make([]uint, capacity)
+	for i := range capacity {
+		targets[i] = i
+	}
+	return targets
+}
+
+// Folds new piece into these many buffered pieces, chosen uniformly
+// at random, trading information retained for cost of folding
+type FoldIntoRandom uint
+
+func (f FoldIntoRandom) Targets(received, capacity uint) []uint {
+	count := min(uint(f), capacity)
+	targets := make([]uint, 0, count)
+
+	// Floyd's sampling, which costs O(count), instead of shuffling
+	// whole buffer, for picking few of those
+	chosen := make(map[uint]struct{}, count)
+	for j := capacity - count; j < capacity; j++ {
+		i := uint(rand.Intn(int(j + 1)))
+		if _, ok := chosen[i]; ok {
+			i = j
+		}
+		chosen[i] = struct{}{}
+		targets = append(targets, i)
+	}
+	return targets
+}
+
+// Folds each new piece into single buffered piece, going round the
+// buffer, so that each buffered piece combines disjoint set of received
+// pieces, which costs one row operation per fold
+type FoldRoundRobin struct{}
+
+func (FoldRoundRobin) Targets(received, capacity uint) []uint {
+	return []uint{received % capacity}
+}
+
+// Recoder, for relays with limited memory, which holds at most `capacity`
+// many coded pieces. Once buffer is full, new pieces are folded into
+// buffered ones i.e. added to those, after being scaled by random nonzero
+// coefficients, as decided by pluggable policy, so that information they
+// carry is kept, instead of being dropped
+type BoundedFullRLNCRecoder struct {
+	capacity uint
+	received uint
+	policy   FoldPolicy
+	field    field.Field
+	pieces   []*kodr_internals.CodedPiece
+}
+
+// Maximum #-of coded pieces, buffer can hold
+func (r *BoundedFullRLNCRecoder) Capacity() uint {
+	return r.capacity
+}
+
+// #-of coded pieces, buffer holds right now
+func (r *BoundedFullRLNCRecoder) Len() uint {
+	return uint(len(r.pieces))
+}
+
+// Received - How many coded pieces are received so far, including
+// ones folded into buffer
+func (r *BoundedFullRLNCRecoder) Received() uint {
+	return r.received
+}
+
+// AddPiece - Adds a new received coded piece to buffer, if there's room,
+// otherwise folds it into buffered pieces, chosen by policy
+//
+// Note: Coded piece is copied, caller's slices are never modified
+func (r *BoundedFullRLNCRecoder) AddPiece(piece *kodr_internals.CodedPiece) error {
+	if piece.Field != 0 && piece.Field != r.field.ID() {
+		return kodr.ErrFieldMismatch
+	}
+	if len(r.pieces) > 0 && len(piece.Vector) != len(r.pieces[0].Vector) {
+		return kodr.ErrCodingVectorLengthMismatch
+	}
+	if len(r.pieces) > 0 && len(piece.Piece) != len(r.pieces[0].Piece) {
+		return kodr.ErrCodedDataLengthMismatch
+	}
+	if len(r.pieces) > 0 && piece.Version != r.pieces[0].Version {
+		return kodr.ErrVersionMismatch
+	}
+
+	if r.Len() < r.capacity {
+		vector := make(kodr_internals.CodingVector, len(piece.Vector))
+		copy(vector, piece.Vector)
+		c_piece := make(kodr_internals.Piece, len(piece.Piece))
+		copy(c_piece, piece.Piece)
+
+		r.pieces = append(r.pieces, &kodr_internals.CodedPiece{Vector: vector, Piece: c_piece, Field: r.field.ID(), Version: piece.Version})
+		r.received++
+		return nil
+	}
+
+	for _, i := range r.policy.Targets(r.received, r.capacity) {
+		c := r.field.Random()
+		for c == 0 {
+			c = r.field.Random()
+		}
+
+		r.field.MulAddSlice(r.pieces[i].Vector, piece.Vector, c)
+		r.field.MulAddSlice(r.pieces[i].Piece, piece.Piece, c)
+	}
+
+	r.received++
+	return nil
+}
+
+// CodedPiece - Returns recoded piece, which is random linear combination
+// of buffered pieces
+//
+// If buffer is empty, returns error
+func (r *BoundedFullRLNCRecoder) CodedPiece() (*kodr_internals.CodedPiece, error) {
+	if len(r.pieces) == 0 {
+		return nil, kodr.ErrNoInnovativePiece
+	}
+
+	weights := r.field.RandomVector(r.Len())
+	vector := make(kodr_internals.CodingVector, len(r.pieces[0].Vector))
+	piece := make(kodr_internals.Piece, len(r.pieces[0].Piece))
+	for i := range r.pieces {
+		w := r.field.Symbol(weights, uint(i))
+		r.field.MulAddSlice(vector, r.pieces[i].Vector, w)
+		r.field.MulAddSlice(piece, r.pieces[i].Piece, w)
+	}
+
+	return &kodr_internals.CodedPiece{
+		Vector:  vector,
+		Piece:   piece,
+		Field:   r.field.ID(),
+		Version: r.pieces[0].Version,
+	}, nil
+}
+
+// Summary - Compact summary of subspace spanned by buffered pieces,
+// computed on demand, as buffer isn't kept reduced
+func (r *BoundedFullRLNCRecoder) Summary() *matrix.Summary {
+	vectors := make([]kodr_internals.CodingVector, 0, len(r.pieces))
+	for i := range r.pieces {
+		vectors = append(vectors, r.pieces[i].Vector)
+	}
+
+	pieceCount := uint(0)
+	if len(r.pieces) > 0 {
+		pieceCount = uint(len(r.pieces[0].Vector)) / r.field.SymbolSize()
+	}
+	return matrix.NewSummary(r.field, pieceCount, vectors)
+}
+
+// Rank - How many linearly independent pieces buffer holds, which
+// never exceeds its capacity, computed on demand
+func (r *BoundedFullRLNCRecoder) Rank() uint {
+	return r.Summary().Rank()
+}
+
+// Recoder, which holds at most `capacity` many coded pieces, folding
+// new ones into buffered ones, as decided by given policy, once full
+func NewBoundedFullRLNCRecoder(capacity uint, policy FoldPolicy) (*BoundedFullRLNCRecoder, error) {
+	return NewBoundedFullRLNCRecoderWithField(capacity, policy, field.Default())
+}
+
+// Same as `NewBoundedFullRLNCRecoder`, but for recoding pieces,
+// which were coded over given finite field
+func NewBoundedFullRLNCRecoderWithField(capacity uint, policy FoldPolicy, f field.Field) (*BoundedFullRLNCRecoder, error) {
+	if capacity == 0 {
+		return nil, kodr.ErrBadBufferCapacity
+	}
+
+	pieces := make([]*kodr_internals.CodedPiece, 0, capacity)
+	return &BoundedFullRLNCRecoder{capacity: capacity, policy: policy, field: f, pieces: pieces}, nil
+}
+
+// Same as `NewBoundedFullRLNCRecoder`, but for recoding pieces,
+// which were coded over GF(2^16)
+func NewBoundedFullRLNCRecoderGf65536(capacity uint, policy FoldPolicy) (*BoundedFullRLNCRecoder, error) {
+	return NewBoundedFullRLNCRecoderWithField(capacity, policy, gf65536.DefaultField())
+}
@@ -0,0 +1,222 @@
+package full_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/full"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf65536"
+)
+
+// Relay, with buffer of B pieces, receives N systematic ( uncoded ) pieces,
+// which can only be kept by folding those into buffered ones, so buffer's
+// rank must be min(B, N) i.e. rank loss must be N - min(B, N), for every
+// policy, while sink decodes everything relay retained
+func TestBoundedFullRLNCRecoderRankLoss(t *testing.T) {
+	var (
+		pieceCount  uint = 64
+		pieceLength uint = 32
+		pieces           = generatePieces(pieceCount, pieceLength)
+		policies         = []full.FoldPolicy{full.FoldIntoAll{}, full.FoldIntoRandom(2), full.FoldRoundRobin{}}
+	)
+
+	// unit coding vectors, one per original piece
+	vectors := make([]kodr_internals.CodingVector, 0, pieceCount)
+	for i := range pieceCount {
+		vector := make(kodr_internals.CodingVector, pieceCount)
+		vector[i] = 1
+		vectors = append(vectors, vector)
+	}
+	coded := codeWithVectors(pieces, vectors)
+
+	for _, policy := range policies {
+		for _, capacity := range []uint{8, 16, 32, 64, 128} {
+			rec, err := full.NewBoundedFullRLNCRecoder(capacity, policy)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			for _, c_piece := range coded {
+				if err := rec.AddPiece(c_piece); err != nil {
+					t.Fatal(err.Error())
+				}
+			}
+
+			if rec.Len() != min(capacity, pieceCount) || rec.Received() != pieceCount {
+				t.Fatalf("expected %d buffered, %d received, found %d, %d\n", min(capacity, pieceCount), pieceCount, rec.Len(), rec.Received())
+			}
+
+			loss := pieceCount - rec.Rank()
+			t.Logf("%-24s B = %3d, rank loss = %2d\n", fmt.Sprintf("%T", policy), capacity, loss)
+			if loss != pieceCount-min(capacity, pieceCount) {
+				t.Fatalf("%T with capacity %d: expected rank loss %d, found %d\n", policy, capacity, pieceCount-min(capacity, pieceCount), loss)
+			}
+
+			dec := full.NewFullRLNCDecoder(pieceCount)
+			for dec.Rank() < rec.Rank() {
+				r_piece, err := rec.CodedPiece()
+				if err != nil {
+					t.Fatal(err.Error())
+				}
+				if err := dec.AddPiece(r_piece); err != nil {
+					t.Fatal(err.Error())
+				}
+			}
+
+			if capacity < pieceCount {
+				continue
+			}
+			d_pieces, err := dec.GetPieces()
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			for i := range pieceCount {
+				if !bytes.Equal(pieces[i], d_pieces[i]) {
+					t.Fatal("decoded data doesn't match !")
+				}
+			}
+		}
+	}
+}
+
+// Buffer filled with copies of same piece holds nothing useful, but
+// later pieces, being folded in, must raise its rank, instead of being dropped
+//
+// Coding is performed over GF(2^16), as random folding coefficients turn out
+// linearly dependent with probability ~1/|F|, which is not negligible for GF(2^8)
+func TestBoundedFullRLNCRecoderFolding(t *testing.T) {
+	var (
+		pieceCount  uint = 32
+		pieceLength uint = 32
+		capacity    uint = 8
+		pieces           = generatePieces(pieceCount, pieceLength)
+	)
+
+	enc, err := full.NewFullRLNCEncoderWithField(pieces, gf65536.DefaultField())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	rec, err := full.NewBoundedFullRLNCRecoderGf65536(capacity, full.FoldIntoAll{})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := rec.CodedPiece(); !errors.Is(err, kodr.ErrNoInnovativePiece) {
+		t.Fatalf("expected: %s\n", kodr.ErrNoInnovativePiece)
+	}
+
+	c_piece := enc.CodedPiece()
+	for range capacity {
+		if err := rec.AddPiece(c_piece); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+	if rec.Rank() != 1 {
+		t.Fatalf("expected rank 1, found %d\n", rec.Rank())
+	}
+
+	for range capacity - 1 {
+		if err := rec.AddPiece(enc.CodedPiece()); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+	if rec.Rank() != capacity {
+		t.Fatalf("expected rank %d, found %d\n", capacity, rec.Rank())
+	}
+
+	short := enc.CodedPiece()
+	short.Piece = short.Piece[:pieceLength-2]
+	if err := rec.AddPiece(short); !errors.Is(err, kodr.ErrCodedDataLengthMismatch) {
+		t.Fatalf("expected: %s\n", kodr.ErrCodedDataLengthMismatch)
+	}
+
+	if _, err := full.NewBoundedFullRLNCRecoder(0, full.FoldIntoAll{}); !errors.Is(err, kodr.ErrBadBufferCapacity) {
+		t.Fatalf("expected: %s\n", kodr.ErrBadBufferCapacity)
+	}
+}
+
+// Buffer of B pieces, filled with copies of same piece, followed by B - 1
+// random ones, where policies differ. Folding into all or going round
+// the buffer reaches every copy, restoring full rank, while folding into
+// a random one keeps hitting some copies more than once, leaving others
+// untouched, so some rank is lost. Coded over GF(2^16), for same reason
+// as above
+func TestBoundedFullRLNCRecoderPolicies(t *testing.T) {
+	var (
+		pieceCount  uint = 64
+		pieceLength uint = 32
+		capacity    uint = 16
+	)
+
+	enc, err := full.NewFullRLNCEncoderWithField(generatePieces(pieceCount, pieceLength), gf65536.DefaultField())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// buffer is filled with copies of one piece, then B - 1 more pieces
+	// are folded in, as decided by policy
+	rank := func(policy full.FoldPolicy) uint {
+		rec, err := full.NewBoundedFullRLNCRecoderGf65536(capacity, policy)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		c_piece := enc.CodedPiece()
+		for range capacity {
+			if err := rec.AddPiece(c_piece); err != nil {
+				t.Fatal(err.Error())
+			}
+		}
+		for range capacity - 1 {
+			if err := rec.AddPiece(enc.CodedPiece()); err != nil {
+				t.Fatal(err.Error())
+			}
+		}
+
+		t.Logf("%-24T B = %3d, rank = %2d\n", policy, capacity, rec.Rank())
+		return rec.Rank()
+	}
+
+	for _, policy := range []full.FoldPolicy{full.FoldIntoAll{}, full.FoldRoundRobin{}} {
+		if r := rank(policy); r != capacity {
+			t.Fatalf("expected rank %d, when folding with %T, found %d\n", capacity, policy, r)
+		}
+	}
+
+	// every random target being distinct is possible, though it happens
+	// with probability 16! / 16^15 < 2 x 10^-5, so it mustn't happen in
+	// most of trials
+	var (
+		trials uint = 16
+		short  uint = 0
+	)
+	for range trials {
+		if rank(full.FoldIntoRandom(1)) < capacity {
+			short++
+		}
+	}
+	if short < trials/2 {
+		t.Fatalf("expected rank < %d in most of %d trials, when folding into random one, found in %d\n", capacity, trials, short)
+	}
+}
+
+func TestFoldIntoRandomTargets(t *testing.T) {
+	var capacity uint = 16
+
+	for _, count := range []uint{0, 1, 5, capacity, capacity + 4} {
+		targets := full.FoldIntoRandom(count).Targets(0, capacity)
+		if uint(len(targets)) != min(count, capacity) {
+			t.Fatalf("expected %d targets, found %d\n", min(count, capacity), len(targets))
+		}
+
+		seen := make(map[uint]bool, len(targets))
+		for _, i := range targets {
+			if i >= capacity || seen[i] {
+				t.Fatalf("expected distinct targets in [0, %d), found %v\n", capacity, targets)
+			}
+			seen[i] = true
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package full
+
+import (
+	"bytes"
+	"encoding/binary"
+	"slices"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+	"github.com/itzmeanjan/kodr/kodr_internals/matrix"
+)
+
+type CachedFullRLNCDecoder struct {
+	expected uint
+	pieces   []*kodr_internals.CodedPiece
+	decoded  []kodr_internals.Piece
+	cache    *matrix.InverseCache
+	field    field.Field
+}
+
+// IsDecoded - Use it for checking whether more piece
+// collection is required or not
+func (d *CachedFullRLNCDecoder) IsDecoded() bool {
+	return d.decoded != nil
+}
+
+// Required - At least how many more coded pieces are required
+// for successfully decoding pieces ?
+//
+// Note: Because received pieces are not eliminated as they arrive,
+// linearly dependent pieces can't be spotted before trying to decode,
+// which is why returned value is only a lower bound, until those are
+// dropped by unsuccessful decoding attempt
+func (d *CachedFullRLNCDecoder) Required() uint {
+	if d.IsDecoded() {
+		return 0
+	}
+	if received := uint(len(d.pieces)); received < d.expected {
+		return d.expected - received
+	}
+	return 1
+}
+
+// AddPiece - Adds a new received coded piece. Received pieces
+// are only buffered until at least N-many are collected, then
+// decoding matrix for received set of coding vectors is looked up
+// in cache. If found, decoding is just a matrix multiplication,
+// otherwise it's computed ( by Gaussian elimination ) & cached
+// for next generations, having same set of coding vectors
+//
+// If received set of coding vectors isn't of full rank, linearly
+// dependent pieces are dropped, so that decoding is attempted again
+// only after as many new pieces are received
+func (d *CachedFullRLNCDecoder) AddPiece(piece *kodr_internals.CodedPiece) error {
+	if d.IsDecoded() {
+		return kodr.ErrAllUsefulPiecesReceived
+	}
+
+	if piece.Field != 0 && piece.Field != d.field.ID() {
+		return kodr.ErrFieldMismatch
+	}
+	if uint(len(piece.Vector)) != d.expected*d.field.SymbolSize() {
+		return kodr.ErrCodingVectorLengthMismatch
+	}
+	if len(d.pieces) > 0 && len(piece.Piece) != len(d.pieces[0].Piece) {
+		return kodr.ErrCodedDataLengthMismatch
+	}
+
+	d.pieces = append(d.pieces, piece)
+	if uint(len(d.pieces)) < d.expected {
+		return nil
+	}
+
+	// ordering received pieces by coding vector, so that
+	// same set of vectors, received in any order, maps to same key
+	sorted := slices.Clone(d.pieces)
+	slices.SortStableFunc(sorted, func(a, b *kodr_internals.CodedPiece) int {
+		return bytes.Compare(a.Vector, b.Vector)
+	})
+
+	// field identifier is part of key, so that decoders working over
+	// different fields can share cache
+	key := make([]byte, 0, 4+uint(len(sorted))*uint(len(piece.Vector)))
+	key = binary.BigEndian.AppendUint32(key, d.field.ID())
+	for i := range sorted {
+		key = append(key, sorted[i].Vector...)
+	}
+
+	inverse, ok := d.cache.Get(string(key))
+	if !ok {
+		coeffs := make(matrix.Matrix, len(sorted))
+		for i := range sorted {
+			coeffs[i] = sorted[i].Vector
+		}
+
+		var err error
+		if inverse, err = matrix.DecodingMatrixWithField(d.field, coeffs, d.expected); err != nil {
+			// not yet decodable, some pieces are linearly dependent
+			d.prune()
+			return nil
+		}
+		d.cache.Put(string(key), inverse)
+	}
+
+	decoded := make([]kodr_internals.Piece, d.expected)
+	for i := range decoded {
+		decoded[i] = make(kodr_internals.Piece, len(sorted[0].Piece))
+		for j := range sorted {
+			c := d.field.Symbol(inverse[i], uint(j))
+			if c == 0 {
+				continue
+			}
+			d.field.MulAddSlice(decoded[i], sorted[j].Piece, c)
+		}
+	}
+
+	d.decoded = decoded
+	return nil
+}
+
+// Drops buffered pieces, whose coding vectors are linearly dependent on
+// those of pieces received before, so that buffer only holds linearly
+// independent pieces
+func (d *CachedFullRLNCDecoder) prune() {
+	var (
+		basis  = make([]kodr_internals.CodingVector, 0, len(d.pieces))
+		pivots = make([]uint, 0, len(d.pieces))
+		kept   = d.pieces[:0]
+	)
+
+	for _, piece := range d.pieces {
+		residual := make(kodr_internals.CodingVector, len(piece.Vector))
+		copy(residual, piece.Vector)
+
+		// each basis vector is zero at pivots of ones kept before it,
+		// so eliminating in same order zeroes residual at all pivots
+		for k := range basis {
+			if c := d.field.Symbol(residual, pivots[k]); c != 0 {
+				d.field.MulAddSlice(residual, basis[k], d.field.Sub(0, c))
+			}
+		}
+
+		pivot := d.expected
+		for col := range d.expected {
+			if d.field.Symbol(residual, col) != 0 {
+				pivot = col
+				break
+			}
+		}
+		if pivot == d.expected {
+			continue
+		}
+
+		inv, _ := d.field.Inv(d.field.Symbol(residual, pivot))
+		d.field.MulSlice(residual, inv)
+
+		basis = append(basis, residual)
+		pivots = append(pivots, pivot)
+		kept = append(kept, piece)
+	}
+
+	clear(d.pieces[len(kept):])
+	d.pieces = kept
+}
+
+// GetPieces - Get a list of all decoded pieces, given full
+// decoding has happened
+func (d *CachedFullRLNCDecoder) GetPieces() ([]kodr_internals.Piece, error) {
+	if !d.IsDecoded() {
+		return nil, kodr.ErrMoreUsefulPiecesRequired
+	}
+
+	return d.decoded, nil
+}
+
+// Decoder for one generation of N-many pieces, which shares
+// inverse cache with decoders of other generations. Useful when
+// coding vectors repeat across generations, say because they're
+// generated from same seeds
+func NewCachedFullRLNCDecoder(pieceCount uint, cache *matrix.InverseCache) *CachedFullRLNCDecoder {
+	return NewCachedFullRLNCDecoderWithField(pieceCount, cache, field.Default())
+}
+
+// Same as `NewCachedFullRLNCDecoder`, but for decoding pieces coded
+// over given finite field. Cache can be shared among decoders working
+// over different fields
+func NewCachedFullRLNCDecoderWithField(pieceCount uint, cache *matrix.InverseCache, f field.Field) *CachedFullRLNCDecoder {
+	return &CachedFullRLNCDecoder{expected: pieceCount, cache: cache, field: f}
+}
@@ -0,0 +1,188 @@
+package full_test
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/full"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf256"
+	"github.com/itzmeanjan/kodr/kodr_internals/matrix"
+)
+
+// Codes pieces using already known coding vectors, as if
+// they were generated from same seeds for every generation
+func codeWithVectors(pieces []kodr_internals.Piece, vectors []kodr_internals.CodingVector) []*kodr_internals.CodedPiece {
+	return codeWithVectorsOver(field.Default(), pieces, vectors)
+}
+
+// Same as `codeWithVectors`, but coding is performed over given finite field
+func codeWithVectorsOver(f field.Field, pieces []kodr_internals.Piece, vectors []kodr_internals.CodingVector) []*kodr_internals.CodedPiece {
+	coded := make([]*kodr_internals.CodedPiece, 0, len(vectors))
+	for _, vector := range vectors {
+		piece := make(kodr_internals.Piece, len(pieces[0]))
+		for i := range pieces {
+			f.MulAddSlice(piece, pieces[i], f.Symbol(vector, uint(i)))
+		}
+		coded = append(coded, &kodr_internals.CodedPiece{Vector: vector, Piece: piece})
+	}
+	return coded
+}
+
+// N random coding vectors, which are linearly independent over
+// GF(2^8), no matter which irreducible polynomial is used, as those
+// are unit vectors, scaled by random nonzero coefficients
+func independentVectors(pieceCount uint) []kodr_internals.CodingVector {
+	vectors := make([]kodr_internals.CodingVector, 0, pieceCount)
+	for i := range pieceCount {
+		vector := make(kodr_internals.CodingVector, pieceCount)
+		vector[i] = byte(1 + rand.Intn(255))
+		vectors = append(vectors, vector)
+	}
+	return vectors
+}
+
+func TestCachedFullRLNCDecoder(t *testing.T) {
+	var (
+		pieceCount      uint = 32
+		pieceLength     uint = 1 << 10
+		codedPieceCount      = pieceCount + 2
+		generations          = 8
+	)
+
+	vectors := make([]kodr_internals.CodingVector, 0, codedPieceCount)
+	for range codedPieceCount {
+		vectors = append(vectors, kodr_internals.GenerateCodingVector(pieceCount))
+	}
+
+	cache := matrix.NewInverseCache(4, matrix.EvictLRU)
+	for range generations {
+		pieces := generatePieces(pieceCount, pieceLength)
+		coded := codeWithVectors(pieces, vectors)
+		// same pieces are lost in every generation, though ordering
+		// of received pieces shouldn't matter
+		rand.Shuffle(int(pieceCount), func(i, j int) {
+			coded[i], coded[j] = coded[j], coded[i]
+		})
+
+		dec := full.NewCachedFullRLNCDecoder(pieceCount, cache)
+		for i := range coded {
+			if _, err := dec.GetPieces(); !dec.IsDecoded() && !(err != nil && errors.Is(err, kodr.ErrMoreUsefulPiecesRequired)) {
+				t.Fatal("expected error indicating more pieces are required for decoding")
+			}
+
+			if err := dec.AddPiece(coded[i]); errors.Is(err, kodr.ErrAllUsefulPiecesReceived) {
+				break
+			}
+		}
+
+		d_pieces, err := dec.GetPieces()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		for i := range pieces {
+			if !bytes.Equal(pieces[i], d_pieces[i]) {
+				t.Fatal("decoded data doesn't match !")
+			}
+		}
+	}
+
+	if hits, _ := cache.Stats(); hits == 0 {
+		t.Fatal("expected cached decoding matrix to be reused")
+	}
+}
+
+func TestCachedFullRLNCDecoderDependentPieces(t *testing.T) {
+	var (
+		pieceCount  uint = 8
+		pieceLength uint = 1 << 8
+	)
+
+	pieces := generatePieces(pieceCount, pieceLength)
+	vectors := independentVectors(pieceCount)
+	// first piece is received thrice, so that buffer gets full,
+	// before all linearly independent pieces are received
+	vectors = append(vectors[:1], append([]kodr_internals.CodingVector{vectors[0], vectors[0]}, vectors[1:]...)...)
+	coded := codeWithVectors(pieces, vectors)
+
+	cache := matrix.NewInverseCache(4, matrix.EvictLRU)
+	dec := full.NewCachedFullRLNCDecoder(pieceCount, cache)
+
+	if err := dec.AddPiece(&kodr_internals.CodedPiece{Vector: make(kodr_internals.CodingVector, pieceCount-1)}); !errors.Is(err, kodr.ErrCodingVectorLengthMismatch) {
+		t.Fatalf("expected: %s\n", kodr.ErrCodingVectorLengthMismatch)
+	}
+
+	for i := range coded {
+		if err := dec.AddPiece(coded[i]); err != nil {
+			t.Fatal(err.Error())
+		}
+		// both duplicates are dropped, as soon as buffer gets full
+		if uint(i) == pieceCount-1 && dec.Required() != 2 {
+			t.Fatalf("expected 2 more pieces to be required, but %d", dec.Required())
+		}
+	}
+
+	d_pieces, err := dec.GetPieces()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	for i := range pieces {
+		if !bytes.Equal(pieces[i], d_pieces[i]) {
+			t.Fatal("decoded data doesn't match !")
+		}
+	}
+
+	// decoding is attempted once, when buffer gets full & once,
+	// when it's full again, after dropping dependent pieces
+	if _, misses := cache.Stats(); misses != 2 {
+		t.Fatalf("expected 2 cache misses, but %d", misses)
+	}
+}
+
+func TestCachedFullRLNCDecoderSharedAmongFields(t *testing.T) {
+	var (
+		pieceCount  uint = 8
+		pieceLength uint = 1 << 8
+	)
+
+	aes, err := gf256.NewField(gf256.AESPolynomial, gf256.AESGenerator)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	vectors := independentVectors(pieceCount)
+
+	// same coding vectors, though coefficients are elements of
+	// different fields, so decoding matrices mustn't be mixed up
+	cache := matrix.NewInverseCache(4, matrix.EvictLRU)
+	for _, f := range []field.Field{field.Default(), aes} {
+		pieces := generatePieces(pieceCount, pieceLength)
+		coded := codeWithVectorsOver(f, pieces, vectors)
+
+		dec := full.NewCachedFullRLNCDecoderWithField(pieceCount, cache, f)
+		for i := range coded {
+			if err := dec.AddPiece(coded[i]); err != nil {
+				t.Fatal(err.Error())
+			}
+		}
+
+		d_pieces, err := dec.GetPieces()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		for i := range pieces {
+			if !bytes.Equal(pieces[i], d_pieces[i]) {
+				t.Fatal("decoded data doesn't match !")
+			}
+		}
+	}
+
+	if cache.Len() != 2 {
+		t.Fatalf("expected 2 cached decoding matrices, but %d", cache.Len())
+	}
+}
@@ -1,14 +1,21 @@
 package full
 
 import (
+	"encoding/binary"
+
 	"github.com/itzmeanjan/kodr"
 	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf65536"
 	"github.com/itzmeanjan/kodr/kodr_internals/matrix"
 )
 
 type FullRLNCDecoder struct {
 	expected, useful, received uint
-	state                      *matrix.DecoderState
+	version                    uint64
+	state                      matrix.State
+	reported                   []bool
+	onDecodable                func(idx uint)
 }
 
 // PieceLength - Returns piece length in bytes
@@ -16,12 +23,7 @@ type FullRLNCDecoder struct {
 // If no pieces are yet added to decoder state, then
 // returns 0, denoting **unknown**
 func (d *FullRLNCDecoder) PieceLength() uint {
-	if d.received > 0 {
-		coded := d.state.CodedPieceMatrix()
-		return coded.Cols()
-	}
-
-	return 0
+	return d.state.PieceLength()
 }
 
 // IsDecoded - Use it for checking whether more piece
@@ -39,6 +41,23 @@ func (d *FullRLNCDecoder) Required() uint {
 	return d.expected - d.useful
 }
 
+// Rank - How many linearly independent pieces are received so far
+func (d *FullRLNCDecoder) Rank() uint {
+	return d.useful
+}
+
+// Received - How many coded pieces are received so far, including
+// linearly dependent ones
+func (d *FullRLNCDecoder) Received() uint {
+	return d.received
+}
+
+// Feedback - Serialized rank of decoder, which can be sent back to
+// sender, so that it can adapt coding density to decoder's progress
+func (d *FullRLNCDecoder) Feedback() []byte {
+	return binary.AppendUvarint(nil, uint64(d.Rank()))
+}
+
 // AddPiece - Adds a new received coded piece along with
 // coding vector. After every new coded piece reception
 // augmented matrix ( coding vector + coded piece )
@@ -53,18 +72,117 @@ func (d *FullRLNCDecoder) AddPiece(piece *kodr_internals.CodedPiece) error {
 		return kodr.ErrAllUsefulPiecesReceived
 	}
 
+	// piece coded over some other field, would only corrupt decoder state
+	if piece.Field != 0 && piece.Field != d.state.Field().ID() {
+		return kodr.ErrFieldMismatch
+	}
+
+	if uint(len(piece.Vector)) != d.expected*d.state.Field().SymbolSize() {
+		return kodr.ErrCodingVectorLengthMismatch
+	}
+	if l := d.state.PieceLength(); l != 0 && uint(len(piece.Piece)) != l {
+		return kodr.ErrCodedDataLengthMismatch
+	}
+
+	// pieces coded from different versions of object, mustn't be mixed
+	if d.received > 0 && piece.Version != d.version {
+		return kodr.ErrVersionMismatch
+	}
+	d.version = piece.Version
+
 	d.state.AddPiece(piece)
 	d.received++
 	if !(d.received > 1) {
 		d.useful++
+		d.notify()
 		return nil
 	}
 
 	d.state.Rref()
 	d.useful = d.state.Rank()
+	d.notify()
+	return nil
+}
+
+// Merge - Absorbs all pieces received by other decoder, decoding same
+// object, say one left behind by an earlier, interrupted session, without
+// replaying those pieces one by one. Both bases & coded pieces are merged
+// and reduced once, while received piece count becomes sum of both and
+// useful piece count becomes rank of merged state
+//
+// Note: Both decoders must be backed by dense `matrix.DecoderState`, other
+// decoder is left untouched
+func (d *FullRLNCDecoder) Merge(other *FullRLNCDecoder) error {
+	if d.expected != other.expected {
+		return kodr.ErrDecoderMismatch
+	}
+	if d.received > 0 && other.received > 0 && d.version != other.version {
+		return kodr.ErrVersionMismatch
+	}
+
+	state, ok := d.state.(*matrix.DecoderState)
+	if !ok {
+		return kodr.ErrDecoderMismatch
+	}
+	other_state, ok := other.state.(*matrix.DecoderState)
+	if !ok {
+		return kodr.ErrDecoderMismatch
+	}
+
+	if err := state.Merge(other_state); err != nil {
+		return err
+	}
+
+	if d.received == 0 {
+		d.version = other.version
+	}
+	d.received += other.received
+	d.useful = state.Rank()
+	d.notify()
 	return nil
 }
 
+// Decodable - Indices of original pieces, which are decodable right now,
+// in increasing order, so that those can be consumed using `GetPiece`,
+// before full rank is reached
+func (d *FullRLNCDecoder) Decodable() []uint {
+	return d.state.Decodable()
+}
+
+// OnDecodable - Registers callback, which is invoked with index of each
+// original piece, as soon as it becomes decodable, while adding coded
+// pieces. Pieces, which are already decodable, are reported on next
+// addition
+func (d *FullRLNCDecoder) OnDecodable(fn func(idx uint)) {
+	d.onDecodable = fn
+}
+
+// Reports original pieces, which became decodable since last call,
+// in increasing order, if some callback is registered
+func (d *FullRLNCDecoder) notify() {
+	if d.onDecodable == nil {
+		return
+	}
+	if d.reported == nil {
+		d.reported = make([]bool, d.expected)
+	}
+
+	for _, idx := range d.state.Decodable() {
+		if d.reported[idx] {
+			continue
+		}
+		d.reported[idx] = true
+		d.onDecodable(idx)
+	}
+}
+
+// Summary - Compact summary of subspace spanned by received coding
+// vectors, which can be sent back to sender, so that it crafts coded
+// pieces guaranteed to be innovative, using `CodedPieceFor`
+func (d *FullRLNCDecoder) Summary() *matrix.Summary {
+	return d.state.Summary()
+}
+
 // GetPiece - Get a decoded piece by index, may ( not ) succeed !
 //
 // Note: It's not necessary that full decoding needs to happen
@@ -110,3 +228,23 @@ func NewFullRLNCDecoder(pieceCount uint) *FullRLNCDecoder {
 	state := matrix.NewDecoderStateWithPieceCount(pieceCount)
 	return &FullRLNCDecoder{expected: pieceCount, state: state}
 }
+
+// Same as `NewFullRLNCDecoder`, but for decoding pieces
+// coded ( or recoded ) over given finite field
+func NewFullRLNCDecoderWithField(pieceCount uint, f field.Field) *FullRLNCDecoder {
+	state := matrix.NewDecoderStateWithField(pieceCount, f)
+	return &FullRLNCDecoder{expected: pieceCount, state: state}
+}
+
+// Same as `NewFullRLNCDecoder`, but elimination is carried out by
+// given decoder state, say `matrix.SparseDecoderState`, which suits
+// sparse coding vectors, built for same #-of pieces
+func NewFullRLNCDecoderWithState(pieceCount uint, state matrix.State) *FullRLNCDecoder {
+	return &FullRLNCDecoder{expected: pieceCount, state: state}
+}
+
+// Same as `NewFullRLNCDecoder`, but for decoding pieces
+// coded ( or recoded ) over GF(2^16)
+func NewFullRLNCDecoderGf65536(pieceCount uint) *FullRLNCDecoder {
+	return NewFullRLNCDecoderWithField(pieceCount, gf65536.DefaultField())
+}
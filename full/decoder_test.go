@@ -3,6 +3,7 @@ package full_test
 import (
 	"bytes"
 	"errors"
+	"slices"
 	"testing"
 
 	"github.com/itzmeanjan/kodr"
@@ -72,3 +73,87 @@ func TestNewFullRLNCDecoder(t *testing.T) {
 		}
 	}
 }
+
+// Coding vectors of growing support reveal pieces one by one, each
+// of which must be reported exactly once, while already being decodable
+func TestFullRLNCDecoderOnDecodable(t *testing.T) {
+	var (
+		pieceCount  uint = 16
+		pieceLength uint = 64
+		pieces           = generatePieces(pieceCount, pieceLength)
+		dec              = full.NewFullRLNCDecoder(pieceCount)
+	)
+
+	vectors := make([]kodr_internals.CodingVector, 0, pieceCount)
+	for i := range pieceCount {
+		vector := make(kodr_internals.CodingVector, pieceCount)
+		for j := range i + 1 {
+			vector[j] = byte(j + 1)
+		}
+		vectors = append(vectors, vector)
+	}
+
+	reported := make([]uint, 0, pieceCount)
+	dec.OnDecodable(func(idx uint) {
+		piece, err := dec.GetPiece(idx)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if !bytes.Equal(piece, pieces[idx]) {
+			t.Fatal("decoded data doesn't match !")
+		}
+		reported = append(reported, idx)
+	})
+
+	// last piece first, so that nothing is decodable till the end
+	coded := codeWithVectors(pieces, vectors)
+	if err := dec.AddPiece(coded[pieceCount-1]); err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(reported) != 0 || len(dec.Decodable()) != 0 {
+		t.Fatalf("expected nothing decodable, found %v\n", reported)
+	}
+
+	for i := range pieceCount - 1 {
+		if err := dec.AddPiece(coded[i]); err != nil {
+			t.Fatal(err.Error())
+		}
+
+		// each one reveals one more piece, except for the last one,
+		// which reveals two, thanks to piece added first
+		if !slices.Equal(dec.Decodable(), reported) {
+			t.Fatalf("decodable pieces %v != reported %v\n", dec.Decodable(), reported)
+		}
+	}
+
+	if uint(len(reported)) != pieceCount || !slices.IsSorted(reported) {
+		t.Fatalf("expected each piece reported once, in order, found %v\n", reported)
+	}
+}
+
+func TestFullRLNCDecoderLengthMismatch(t *testing.T) {
+	var (
+		pieceCount  uint = 8
+		pieceLength uint = 64
+		enc              = full.NewFullRLNCEncoder(generatePieces(pieceCount, pieceLength))
+		dec              = full.NewFullRLNCDecoder(pieceCount)
+	)
+
+	piece := enc.CodedPiece()
+	short := &kodr_internals.CodedPiece{Vector: piece.Vector[:pieceCount-1], Piece: piece.Piece}
+	if err := dec.AddPiece(short); !errors.Is(err, kodr.ErrCodingVectorLengthMismatch) {
+		t.Fatalf("expected: %s\n", kodr.ErrCodingVectorLengthMismatch)
+	}
+	if err := dec.AddPiece(piece); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	piece = enc.CodedPiece()
+	short = &kodr_internals.CodedPiece{Vector: piece.Vector, Piece: piece.Piece[:pieceLength-1]}
+	if err := dec.AddPiece(short); !errors.Is(err, kodr.ErrCodedDataLengthMismatch) {
+		t.Fatalf("expected: %s\n", kodr.ErrCodedDataLengthMismatch)
+	}
+	if dec.Received() != 1 {
+		t.Fatalf("expected 1 piece received, found %d\n", dec.Received())
+	}
+}
@@ -0,0 +1,145 @@
+package full_test
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/full"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf65536"
+	"github.com/itzmeanjan/kodr/kodr_internals/matrix"
+)
+
+// Stored coded pieces are brought up to date by applying deltas of a few
+// updates, after which those must decode to updated object, while stale
+// pieces must neither be mixed in, nor be updated twice
+func TestFullRLNCEncoderUpdate(t *testing.T) {
+	for _, f := range []field.Field{field.Default(), gf65536.DefaultField()} {
+		var (
+			pieceCount  uint = 32
+			pieceLength uint = 64
+			pieces           = generatePieces(pieceCount, pieceLength)
+		)
+
+		enc, err := full.NewFullRLNCEncoderWithField(pieces, f)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		stored := make([]*kodr_internals.CodedPiece, 0, pieceCount+8)
+		for range pieceCount + 8 {
+			stored = append(stored, enc.CodedPiece())
+		}
+		stale := enc.CodedPiece()
+
+		original := slices.Clone(pieces)
+		updated := generatePieces(3, pieceLength)
+		deltas := make([]*kodr_internals.Delta, 0, len(updated))
+		for i, idx := range []uint{0, 7, 0} {
+			delta, err := enc.Update(idx, updated[i])
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			deltas = append(deltas, delta)
+		}
+		if !slices.EqualFunc(pieces, original, func(a, b kodr_internals.Piece) bool { return bytes.Equal(a, b) }) {
+			t.Fatal("caller's slice of original pieces must be left untouched")
+		}
+
+		expected := slices.Clone(pieces)
+		expected[0], expected[7] = updated[2], updated[1]
+		if enc.Version() != 3 {
+			t.Fatalf("expected version 3, found %d\n", enc.Version())
+		}
+
+		for _, c_piece := range stored {
+			for _, delta := range deltas {
+				if err := delta.Apply(c_piece, f); err != nil {
+					t.Fatal(err.Error())
+				}
+			}
+		}
+
+		// delta applied twice or out of order
+		if err := deltas[2].Apply(stored[0], f); !errors.Is(err, kodr.ErrVersionMismatch) {
+			t.Fatalf("expected: %s\n", kodr.ErrVersionMismatch)
+		}
+		if err := deltas[1].Apply(stale, f); !errors.Is(err, kodr.ErrVersionMismatch) {
+			t.Fatalf("expected: %s\n", kodr.ErrVersionMismatch)
+		}
+
+		dec := full.NewFullRLNCDecoderWithField(pieceCount, f)
+		for _, c_piece := range stored {
+			if err := dec.AddPiece(c_piece); err != nil {
+				if errors.Is(err, kodr.ErrAllUsefulPiecesReceived) {
+					break
+				}
+				t.Fatal(err.Error())
+			}
+
+			if !dec.IsDecoded() {
+				if err := dec.AddPiece(stale); !errors.Is(err, kodr.ErrVersionMismatch) {
+					t.Fatalf("expected: %s\n", kodr.ErrVersionMismatch)
+				}
+			}
+		}
+
+		d_pieces, err := dec.GetPieces()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		for i := range pieceCount {
+			if !bytes.Equal(expected[i], d_pieces[i]) {
+				t.Fatal("decoded data doesn't match updated one !")
+			}
+		}
+
+		if _, err := enc.Update(pieceCount, updated[0]); !errors.Is(err, kodr.ErrPieceOutOfBound) {
+			t.Fatalf("expected: %s\n", kodr.ErrPieceOutOfBound)
+		}
+		if _, err := enc.Update(0, updated[0][1:]); !errors.Is(err, kodr.ErrPieceSizeMismatch) {
+			t.Fatalf("expected: %s\n", kodr.ErrPieceSizeMismatch)
+		}
+	}
+}
+
+// Held pieces coded from different versions of object can't be combined
+func TestFullRLNCRecoderMixedVersions(t *testing.T) {
+	var (
+		pieceCount  uint = 8
+		pieceLength uint = 64
+		enc              = full.NewFullRLNCEncoder(generatePieces(pieceCount, pieceLength))
+	)
+
+	held := make([]*kodr_internals.CodedPiece, 0, pieceCount)
+	for range pieceCount {
+		held = append(held, enc.CodedPiece())
+	}
+	if _, err := enc.Update(0, generatePieces(1, pieceLength)[0]); err != nil {
+		t.Fatal(err.Error())
+	}
+	held[pieceCount-1] = enc.CodedPiece()
+
+	rec := full.NewFullRLNCRecoder(held)
+	if _, err := rec.CodedPiece(); !errors.Is(err, kodr.ErrVersionMismatch) {
+		t.Fatalf("expected: %s\n", kodr.ErrVersionMismatch)
+	}
+	if _, err := rec.SparseCodedPiece(2); !errors.Is(err, kodr.ErrVersionMismatch) {
+		t.Fatalf("expected: %s\n", kodr.ErrVersionMismatch)
+	}
+	if _, err := rec.CodedPieceFor(matrix.NewSummary(field.Default(), pieceCount, nil)); !errors.Is(err, kodr.ErrVersionMismatch) {
+		t.Fatalf("expected: %s\n", kodr.ErrVersionMismatch)
+	}
+
+	r_piece, err := full.NewFullRLNCRecoder(held[pieceCount-1:]).CodedPiece()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if r_piece.Version != 1 {
+		t.Fatalf("expected version 1, found %d\n", r_piece.Version)
+	}
+}
@@ -1,12 +1,21 @@
 package full
 
 import (
+	"math/rand"
+	"slices"
+
+	"github.com/itzmeanjan/kodr"
 	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf65536"
+	"github.com/itzmeanjan/kodr/kodr_internals/matrix"
 )
 
 type FullRLNCEncoder struct {
-	pieces []kodr_internals.Piece
-	extra  uint
+	pieces  []kodr_internals.Piece
+	extra   uint
+	field   field.Field
+	version uint64
 }
 
 // Total #-of pieces being coded together --- denoting
@@ -43,8 +52,11 @@ func (f *FullRLNCEncoder) DecodableLen() uint {
 //
 // Here N = len(pieces), original pieces which are
 // being coded together
+//
+// Note: Each coding coefficient takes `SymbolSize()` bytes of
+// chosen finite field, 1 byte for GF(2^8) & 2 bytes for GF(2^16)
 func (f *FullRLNCEncoder) CodedPieceLen() uint {
-	return f.PieceCount() + f.PieceSize()
+	return f.PieceCount()*f.field.SymbolSize() + f.PieceSize()
 }
 
 // How many extra padding bytes added at end of
@@ -54,27 +66,95 @@ func (f *FullRLNCEncoder) Padding() uint {
 	return f.extra
 }
 
+// Version of object being coded, which is bumped by each `Update`
+// & carried by each coded piece
+func (f *FullRLNCEncoder) Version() uint64 {
+	return f.version
+}
+
+// Replaces original piece at index with given one, of same size, bumping
+// version of object, while returning delta, which holders of coded pieces
+// of previous version apply, to bring those up to date, instead of
+// re-encoding whole object
+//
+// Note: Given piece is copied, so caller can reuse it, while encoder keeps
+// its own slice of original pieces, so caller's slice is never modified
+func (f *FullRLNCEncoder) Update(idx uint, piece kodr_internals.Piece) (*kodr_internals.Delta, error) {
+	if idx >= f.PieceCount() {
+		return nil, kodr.ErrPieceOutOfBound
+	}
+	if uint(len(piece)) != f.PieceSize() {
+		return nil, kodr.ErrPieceSizeMismatch
+	}
+
+	delta := kodr_internals.NewDelta(idx, f.pieces[idx], piece, f.version+1, f.field)
+
+	updated := make(kodr_internals.Piece, len(piece))
+	copy(updated, piece)
+	f.pieces[idx] = updated
+	f.version++
+
+	return delta, nil
+}
+
 // Returns a coded piece, which is constructed on-the-fly
 // by randomly drawing elements from finite field i.e.
 // coding coefficients & performing full-RLNC with
 // all original pieces
 func (f *FullRLNCEncoder) CodedPiece() *kodr_internals.CodedPiece {
-	vector := kodr_internals.GenerateCodingVector(f.PieceCount())
+	vector := f.field.RandomVector(f.PieceCount())
 	piece := make(kodr_internals.Piece, f.PieceSize())
 	for i := range f.pieces {
-		piece.Multiply(f.pieces[i], vector[i])
+		f.field.MulAddSlice(piece, f.pieces[i], f.field.Symbol(vector, uint(i)))
 	}
 	return &kodr_internals.CodedPiece{
-		Vector: vector,
-		Piece:  piece,
+		Vector:  vector,
+		Piece:   piece,
+		Field:   f.field.ID(),
+		Version: f.version,
+	}
+}
+
+// Returns a coded piece, which is guaranteed to raise rank of decoder,
+// whose received subspace is summarized. Random coding vector is drawn
+// as usual, but if it happens to lie in summarized subspace, coefficient
+// of one non-pivot column is bumped, which makes it innovative
+//
+// If summarized decoder has already received N linearly independent
+// pieces, returns error, as nothing can be innovative for it
+func (f *FullRLNCEncoder) CodedPieceFor(summary *matrix.Summary) (*kodr_internals.CodedPiece, error) {
+	if summary.PieceCount() != f.PieceCount() || summary.Field().ID() != f.field.ID() {
+		return nil, kodr.ErrSummaryMismatch
+	}
+
+	free := summary.Free()
+	if len(free) == 0 {
+		return nil, kodr.ErrAllUsefulPiecesReceived
+	}
+
+	vector := f.field.RandomVector(f.PieceCount())
+	if !summary.IsInnovative(vector) {
+		col := free[rand.Intn(len(free))]
+		f.field.SetSymbol(vector, col, f.field.Add(f.field.Symbol(vector, col), 1))
 	}
+
+	piece := make(kodr_internals.Piece, f.PieceSize())
+	for i := range f.pieces {
+		f.field.MulAddSlice(piece, f.pieces[i], f.field.Symbol(vector, uint(i)))
+	}
+	return &kodr_internals.CodedPiece{
+		Vector:  vector,
+		Piece:   piece,
+		Field:   f.field.ID(),
+		Version: f.version,
+	}, nil
 }
 
 // Provide with original pieces on which fullRLNC to be performed
 // & get encoder, to be used for on-the-fly generation
 // to N-many coded pieces
 func NewFullRLNCEncoder(pieces []kodr_internals.Piece) *FullRLNCEncoder {
-	return &FullRLNCEncoder{pieces: pieces}
+	return &FullRLNCEncoder{pieces: slices.Clone(pieces), field: field.Default()}
 }
 
 // If you know #-of pieces you want to code together, invoking
@@ -105,3 +185,76 @@ func NewFullRLNCEncoderWithPieceSize(data []byte, pieceSize uint) (*FullRLNCEnco
 	enc.extra = padding
 	return enc, nil
 }
+
+// Same as `NewFullRLNCEncoder`, but coding happens over given finite
+// field, where each coding coefficient & each symbol of piece takes
+// `f.SymbolSize()` bytes, which is why size of original pieces must be
+// a multiple of symbol size
+func NewFullRLNCEncoderWithField(pieces []kodr_internals.Piece, f field.Field) (*FullRLNCEncoder, error) {
+	for i := range pieces {
+		if uint(len(pieces[i]))%f.SymbolSize() != 0 {
+			return nil, kodr.ErrPieceSizeNotMultipleOfSymbolSize
+		}
+	}
+
+	return &FullRLNCEncoder{pieces: slices.Clone(pieces), field: f}, nil
+}
+
+// Splits whole data chunk into N-pieces, each of length multiple of
+// symbol size, with padding bytes appended at end of last piece, if
+// required & prepares full RLNC encoder, working over given finite field
+func NewFullRLNCEncoderWithFieldAndPieceCount(data []byte, pieceCount uint, f field.Field) (*FullRLNCEncoder, error) {
+	pieces, padding, err := kodr_internals.OriginalPiecesFromDataAndPieceCountWithSymbolSize(data, pieceCount, f.SymbolSize())
+	if err != nil {
+		return nil, err
+	}
+
+	enc, err := NewFullRLNCEncoderWithField(pieces, f)
+	if err != nil {
+		return nil, err
+	}
+
+	enc.extra = padding
+	return enc, nil
+}
+
+// Splits whole data chunk into pieces of N-bytes each, where N must be a
+// multiple of symbol size & prepares full RLNC encoder, working over
+// given finite field
+func NewFullRLNCEncoderWithFieldAndPieceSize(data []byte, pieceSize uint, f field.Field) (*FullRLNCEncoder, error) {
+	pieces, padding, err := kodr_internals.OriginalPiecesFromDataAndPieceSizeWithSymbolSize(data, pieceSize, f.SymbolSize())
+	if err != nil {
+		return nil, err
+	}
+
+	enc, err := NewFullRLNCEncoderWithField(pieces, f)
+	if err != nil {
+		return nil, err
+	}
+
+	enc.extra = padding
+	return enc, nil
+}
+
+// Same as `NewFullRLNCEncoder`, but coding happens over GF(2^16) i.e.
+// each coding coefficient & each symbol of piece is 16-bit wide, which
+// is why size of original pieces must be a multiple of 2
+//
+// Larger field keeps chance of receiving linearly dependent pieces
+// negligible, even when thousands of pieces are coded together
+func NewFullRLNCEncoderGf65536(pieces []kodr_internals.Piece) (*FullRLNCEncoder, error) {
+	return NewFullRLNCEncoderWithField(pieces, gf65536.DefaultField())
+}
+
+// Splits whole data chunk into N-pieces, each of even length, with padding
+// bytes appended at end of last piece, if required & prepares full RLNC
+// encoder, working over GF(2^16)
+func NewFullRLNCEncoderGf65536WithPieceCount(data []byte, pieceCount uint) (*FullRLNCEncoder, error) {
+	return NewFullRLNCEncoderWithFieldAndPieceCount(data, pieceCount, gf65536.DefaultField())
+}
+
+// Splits whole data chunk into pieces of N-bytes each, where N must be a
+// multiple of 2 & prepares full RLNC encoder, working over GF(2^16)
+func NewFullRLNCEncoderGf65536WithPieceSize(data []byte, pieceSize uint) (*FullRLNCEncoder, error) {
+	return NewFullRLNCEncoderWithFieldAndPieceSize(data, pieceSize, gf65536.DefaultField())
+}
@@ -0,0 +1,230 @@
+package full_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/full"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf256"
+)
+
+// GF(2^4) with irreducible polynomial x^4 + x + 1, which is
+// not shipped with library, but plugged in only for testing
+// that encoder, recoder & decoder are generic over finite field
+//
+// Each coding coefficient is kept in its own byte, while each
+// byte of piece holds two symbols ( nibbles )
+type gf16 struct {
+	exp [30]uint32
+	log [16]uint32
+}
+
+var _ field.Field = (*gf16)(nil)
+
+func newGf16() *gf16 {
+	f := &gf16{}
+	x := uint32(1)
+	for i := range 15 {
+		f.exp[i], f.exp[i+15] = x, x
+		f.log[x] = uint32(i)
+
+		x <<= 1
+		if x&0b1_0000 != 0 {
+			x ^= 0b1_0011
+		}
+	}
+	return f
+}
+
+func (f *gf16) ID() uint32 {
+	return 4<<24 | 0b0011<<8 | 2
+}
+
+func (f *gf16) SymbolSize() uint {
+	return 1
+}
+
+func (f *gf16) Add(a, b uint32) uint32 {
+	return a ^ b
+}
+
+func (f *gf16) Sub(a, b uint32) uint32 {
+	return a ^ b
+}
+
+func (f *gf16) Mul(a, b uint32) uint32 {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return f.exp[f.log[a]+f.log[b]]
+}
+
+func (f *gf16) Inv(a uint32) (uint32, error) {
+	if a == 0 {
+		return 0, errors.New("zero has no inverse in GF(2^4)")
+	}
+	return f.exp[15-f.log[a]], nil
+}
+
+func (f *gf16) Random() uint32 {
+	buf := make([]byte, 1)
+	rand.Read(buf)
+	return uint32(buf[0] & 0xf)
+}
+
+func (f *gf16) mulByte(b byte, c uint32) byte {
+	return byte(f.Mul(uint32(b>>4), c)<<4 | f.Mul(uint32(b&0xf), c))
+}
+
+func (f *gf16) MulAddSlice(dst, src []byte, c uint32) {
+	for i := range src {
+		dst[i] ^= f.mulByte(src[i], c)
+	}
+}
+
+func (f *gf16) MulSlice(dst []byte, c uint32) {
+	for i := range dst {
+		dst[i] = f.mulByte(dst[i], c)
+	}
+}
+
+func (f *gf16) Symbol(buf []byte, i uint) uint32 {
+	return uint32(buf[i])
+}
+
+func (f *gf16) SetSymbol(buf []byte, i uint, v uint32) {
+	buf[i] = byte(v)
+}
+
+func (f *gf16) RandomVector(n uint) []byte {
+	vector := make([]byte, n)
+	rand.Read(vector)
+	for i := range vector {
+		vector[i] &= 0xf
+	}
+	return vector
+}
+
+func decodeWithField(t *testing.T, f field.Field, next func() *kodr_internals.CodedPiece, pieceCount uint, pieces []kodr_internals.Piece) {
+	dec := full.NewFullRLNCDecoderWithField(pieceCount, f)
+	for {
+		if err := dec.AddPiece(next()); errors.Is(err, kodr.ErrAllUsefulPiecesReceived) {
+			break
+		}
+	}
+
+	d_pieces, err := dec.GetPieces()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	for i := range pieces {
+		if !bytes.Equal(pieces[i], d_pieces[i]) {
+			t.Fatal("decoded data doesn't match !")
+		}
+	}
+}
+
+func TestFullRLNCWithCustomField(t *testing.T) {
+	var (
+		pieceCount  uint = 32
+		pieceLength uint = 1024
+		pieces           = generatePieces(pieceCount, pieceLength)
+		f                = newGf16()
+	)
+
+	enc, err := full.NewFullRLNCEncoderWithField(pieces, f)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if c_piece := enc.CodedPiece(); c_piece.Len() != pieceCount+pieceLength {
+		t.Fatalf("expected coded piece to be of %dB, found to be of %dB\n", pieceCount+pieceLength, c_piece.Len())
+	}
+
+	t.Run("Encoder", func(t *testing.T) {
+		decodeWithField(t, f, enc.CodedPiece, pieceCount, pieces)
+	})
+
+	t.Run("Recoder", func(t *testing.T) {
+		coded := make([]*kodr_internals.CodedPiece, 0, pieceCount+4)
+		for range pieceCount + 4 {
+			coded = append(coded, enc.CodedPiece())
+		}
+
+		rec := full.NewFullRLNCRecoderWithField(coded, f)
+		next := func() *kodr_internals.CodedPiece {
+			r_piece, err := rec.CodedPiece()
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			return r_piece
+		}
+		decodeWithField(t, f, next, pieceCount, pieces)
+	})
+
+	t.Run("WithPieceSize", func(t *testing.T) {
+		data := generateData(pieceCount * pieceLength)
+		enc, err := full.NewFullRLNCEncoderWithFieldAndPieceSize(data, pieceLength, f)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		pieces, _, err := kodr_internals.OriginalPiecesFromDataAndPieceSize(data, pieceLength)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		decodeWithField(t, f, enc.CodedPiece, pieceCount, pieces)
+	})
+}
+
+func TestFullRLNCFieldMismatch(t *testing.T) {
+	var (
+		pieceCount  uint = 16
+		pieceLength uint = 256
+		pieces           = generatePieces(pieceCount, pieceLength)
+	)
+
+	aes, err := gf256.NewField(gf256.AESPolynomial, gf256.AESGenerator)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	enc, err := full.NewFullRLNCEncoderWithField(pieces, aes)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	dec := full.NewFullRLNCDecoder(pieceCount)
+	if err := dec.AddPiece(enc.CodedPiece()); !errors.Is(err, kodr.ErrFieldMismatch) {
+		t.Fatalf("expected: %s\n", kodr.ErrFieldMismatch)
+	}
+
+	rec := full.NewFullRLNCRecoderWithField([]*kodr_internals.CodedPiece{enc.CodedPiece(), enc.CodedPiece()}, aes)
+	r_piece, err := rec.CodedPiece()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := dec.AddPiece(r_piece); !errors.Is(err, kodr.ErrFieldMismatch) {
+		t.Fatalf("expected: %s\n", kodr.ErrFieldMismatch)
+	}
+
+	t.Run("Recoder", func(t *testing.T) {
+		rec := full.NewFullRLNCRecoder([]*kodr_internals.CodedPiece{enc.CodedPiece(), enc.CodedPiece()})
+		if _, err := rec.CodedPiece(); !errors.Is(err, kodr.ErrFieldMismatch) {
+			t.Fatalf("expected: %s\n", kodr.ErrFieldMismatch)
+		}
+		if _, err := rec.SparseCodedPiece(1); !errors.Is(err, kodr.ErrFieldMismatch) {
+			t.Fatalf("expected: %s\n", kodr.ErrFieldMismatch)
+		}
+	})
+
+	t.Run("SameField", func(t *testing.T) {
+		decodeWithField(t, aes, enc.CodedPiece, pieceCount, pieces)
+	})
+}
@@ -0,0 +1,117 @@
+package full_test
+
+import (
+	"bytes"
+	"errors"
+	math_rand "math/rand"
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/full"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+)
+
+func decodeGf65536(t *testing.T, next func() *kodr_internals.CodedPiece, pieceCount uint, pieces []kodr_internals.Piece) {
+	dec := full.NewFullRLNCDecoderGf65536(pieceCount)
+	for {
+		if err := dec.AddPiece(next()); errors.Is(err, kodr.ErrAllUsefulPiecesReceived) {
+			break
+		}
+	}
+
+	d_pieces, err := dec.GetPieces()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if len(pieces) != len(d_pieces) {
+		t.Fatal("didn't decode all !")
+	}
+
+	for i := range pieces {
+		if !bytes.Equal(pieces[i], d_pieces[i]) {
+			t.Fatal("decoded data doesn't match !")
+		}
+	}
+}
+
+func TestFullRLNCGf65536(t *testing.T) {
+	var (
+		pieceCount  uint = 64
+		pieceLength uint = 1024
+		pieces           = generatePieces(pieceCount, pieceLength)
+	)
+
+	if _, err := full.NewFullRLNCEncoderGf65536(generatePieces(pieceCount, pieceLength+1)); !(err != nil && errors.Is(err, kodr.ErrPieceSizeNotMultipleOfSymbolSize)) {
+		t.Fatalf("expected: %s\n", kodr.ErrPieceSizeNotMultipleOfSymbolSize)
+	}
+
+	enc, err := full.NewFullRLNCEncoderGf65536(pieces)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if c_piece := enc.CodedPiece(); c_piece.Len() != enc.CodedPieceLen() || enc.CodedPieceLen() != 2*pieceCount+pieceLength {
+		t.Fatalf("expected coded piece to be of %dB, found to be of %dB\n", 2*pieceCount+pieceLength, c_piece.Len())
+	}
+
+	t.Run("Encoder", func(t *testing.T) {
+		decodeGf65536(t, enc.CodedPiece, pieceCount, pieces)
+	})
+
+	t.Run("Recoder", func(t *testing.T) {
+		coded := make([]*kodr_internals.CodedPiece, 0, pieceCount+2)
+		for range pieceCount + 2 {
+			coded = append(coded, enc.CodedPiece())
+		}
+
+		rec := full.NewFullRLNCRecoderGf65536(coded)
+		next := func() *kodr_internals.CodedPiece {
+			r_piece, err := rec.CodedPiece()
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			return r_piece
+		}
+		decodeGf65536(t, next, pieceCount, pieces)
+	})
+
+	t.Run("RecoderWithFlattenData", func(t *testing.T) {
+		codedFlattened := make([]byte, 0)
+		for range pieceCount + 2 {
+			codedFlattened = append(codedFlattened, enc.CodedPiece().Flatten()...)
+		}
+
+		rec, err := full.NewFullRLNCRecoderGf65536WithFlattenData(codedFlattened, pieceCount+2, pieceCount)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		next := func() *kodr_internals.CodedPiece {
+			r_piece, err := rec.CodedPiece()
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			return r_piece
+		}
+		decodeGf65536(t, next, pieceCount, pieces)
+	})
+
+	t.Run("WithPieceCount", func(t *testing.T) {
+		size := uint(2<<10 + math_rand.Intn(2<<10))
+		pieceCount := uint(2<<1 + math_rand.Intn(2<<8))
+		data := generateData(size)
+
+		pieces, _, err := kodr_internals.OriginalPiecesFromDataAndPieceCountWithSymbolSize(data, pieceCount, 2)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		enc, err := full.NewFullRLNCEncoderGf65536WithPieceCount(data, pieceCount)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		decodeGf65536(t, enc.CodedPiece, pieceCount, pieces)
+	})
+}
@@ -0,0 +1,140 @@
+package full
+
+import (
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf65536"
+	"github.com/itzmeanjan/kodr/kodr_internals/matrix"
+)
+
+// Recoder, which is fed with coded pieces over time, as relays receive
+// those, keeping only reduced basis of what it holds, so that linearly
+// dependent pieces are dropped & cost of recoding scales with rank,
+// instead of #-of pieces received
+type IncrementalFullRLNCRecoder struct {
+	pieceCount uint
+	received   uint
+	version    uint64
+	state      *matrix.DecoderState
+}
+
+// Rank - How many linearly independent pieces are held so far
+func (r *IncrementalFullRLNCRecoder) Rank() uint {
+	return r.state.Rank()
+}
+
+// Received - How many coded pieces are received so far, including
+// linearly dependent ones
+func (r *IncrementalFullRLNCRecoder) Received() uint {
+	return r.received
+}
+
+// PieceLength - Returns piece length in bytes
+//
+// If no pieces are yet added to recoder, then
+// returns 0, denoting **unknown**
+func (r *IncrementalFullRLNCRecoder) PieceLength() uint {
+	return r.state.PieceLength()
+}
+
+// AddPiece - Adds a new received coded piece, which is reduced against
+// basis held so far & dropped, if it's linearly dependent. All pieces
+// must be coded from same version of object, as first one received
+//
+// Note: Coded piece is copied, caller's slices are never modified
+func (r *IncrementalFullRLNCRecoder) AddPiece(piece *kodr_internals.CodedPiece) error {
+	if r.Rank() >= r.pieceCount {
+		return kodr.ErrAllUsefulPiecesReceived
+	}
+	if piece.Field != 0 && piece.Field != r.state.Field().ID() {
+		return kodr.ErrFieldMismatch
+	}
+	if uint(len(piece.Vector)) != r.pieceCount*r.state.Field().SymbolSize() {
+		return kodr.ErrCodingVectorLengthMismatch
+	}
+	if l := r.PieceLength(); l != 0 && uint(len(piece.Piece)) != l {
+		return kodr.ErrCodedDataLengthMismatch
+	}
+	if r.received > 0 && piece.Version != r.version {
+		return kodr.ErrVersionMismatch
+	}
+	r.version = piece.Version
+
+	vector := make(kodr_internals.CodingVector, len(piece.Vector))
+	copy(vector, piece.Vector)
+	c_piece := make(kodr_internals.Piece, len(piece.Piece))
+	copy(c_piece, piece.Piece)
+
+	r.state.AddPiece(&kodr_internals.CodedPiece{Vector: vector, Piece: c_piece})
+	r.received++
+	r.state.Rref()
+	return nil
+}
+
+// CodedPiece - Returns recoded piece, which is random linear combination
+// of current basis vectors, so it can be invoked any time, while more
+// pieces keep arriving
+//
+// If no piece is held yet, returns error
+func (r *IncrementalFullRLNCRecoder) CodedPiece() (*kodr_internals.CodedPiece, error) {
+	return recodeBasis(r.state, r.version)
+}
+
+// Random linear combination of basis vectors of decoder state, along
+// with respective coded pieces, coded from given version of object,
+// returning error, if it has none
+func recodeBasis(state *matrix.DecoderState, version uint64) (*kodr_internals.CodedPiece, error) {
+	rank := state.Rank()
+	if rank == 0 {
+		return nil, kodr.ErrNoInnovativePiece
+	}
+
+	var (
+		f       = state.Field()
+		coeffs  = state.CoefficientMatrix()
+		coded   = state.CodedPieceMatrix()
+		weights = f.RandomVector(rank)
+		vector  = make(kodr_internals.CodingVector, coeffs.Cols())
+		piece   = make(kodr_internals.Piece, coded.Cols())
+	)
+
+	for i := range rank {
+		w := f.Symbol(weights, i)
+		f.MulAddSlice(vector, coeffs[i], w)
+		f.MulAddSlice(piece, coded[i], w)
+	}
+
+	return &kodr_internals.CodedPiece{
+		Vector:  vector,
+		Piece:   piece,
+		Field:   f.ID(),
+		Version: version,
+	}, nil
+}
+
+// Summary - Compact summary of subspace spanned by held pieces, which
+// can be compared with that of receiver, to find out how many innovative
+// pieces this recoder can give it
+func (r *IncrementalFullRLNCRecoder) Summary() *matrix.Summary {
+	return r.state.Summary()
+}
+
+// Recoder, which is to be fed with pieces coded together with
+// `pieceCount` many others, as those arrive, while recoded pieces
+// can be requested any time
+func NewIncrementalFullRLNCRecoder(pieceCount uint) *IncrementalFullRLNCRecoder {
+	return NewIncrementalFullRLNCRecoderWithField(pieceCount, field.Default())
+}
+
+// Same as `NewIncrementalFullRLNCRecoder`, but for recoding pieces,
+// which were coded over given finite field
+func NewIncrementalFullRLNCRecoderWithField(pieceCount uint, f field.Field) *IncrementalFullRLNCRecoder {
+	return &IncrementalFullRLNCRecoder{pieceCount: pieceCount, state: matrix.NewDecoderStateWithField(pieceCount, f)}
+}
+
+// Same as `NewIncrementalFullRLNCRecoder`, but for recoding pieces,
+// which were coded over GF(2^16)
+func NewIncrementalFullRLNCRecoderGf65536(pieceCount uint) *IncrementalFullRLNCRecoder {
+	return NewIncrementalFullRLNCRecoderWithField(pieceCount, gf65536.DefaultField())
+}
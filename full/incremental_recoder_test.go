@@ -0,0 +1,118 @@
+package full_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/full"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf65536"
+)
+
+// Recoder is fed over time, with every piece being sent twice, while
+// recoded pieces are drawn in between, which must never give decoder
+// more than what recoder holds
+func TestIncrementalFullRLNCRecoder(t *testing.T) {
+	for _, f := range []field.Field{field.Default(), gf65536.DefaultField()} {
+		var (
+			pieceCount  uint = 64
+			pieceLength uint = 128
+			pieces           = generatePieces(pieceCount, pieceLength)
+			rec              = full.NewIncrementalFullRLNCRecoderWithField(pieceCount, f)
+			dec              = full.NewFullRLNCDecoderWithField(pieceCount, f)
+		)
+
+		enc, err := full.NewFullRLNCEncoderWithField(pieces, f)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		if _, err := rec.CodedPiece(); !errors.Is(err, kodr.ErrNoInnovativePiece) {
+			t.Fatalf("expected: %s\n", kodr.ErrNoInnovativePiece)
+		}
+
+		for i := uint(1); !dec.IsDecoded(); i++ {
+			if rec.Rank() < pieceCount {
+				c_piece := enc.CodedPiece()
+				for range 2 {
+					if err := rec.AddPiece(c_piece); err != nil && !errors.Is(err, kodr.ErrAllUsefulPiecesReceived) {
+						t.Fatal(err.Error())
+					}
+				}
+				if rec.Rank() != i {
+					t.Fatalf("expected recoder rank %d, found %d\n", i, rec.Rank())
+				}
+			}
+
+			r_piece, err := rec.CodedPiece()
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if err := dec.AddPiece(r_piece); err != nil {
+				t.Fatal(err.Error())
+			}
+			if dec.Rank() > rec.Rank() {
+				t.Fatalf("decoder rank %d > recoder rank %d\n", dec.Rank(), rec.Rank())
+			}
+		}
+
+		if err := rec.AddPiece(enc.CodedPiece()); !errors.Is(err, kodr.ErrAllUsefulPiecesReceived) {
+			t.Fatalf("expected: %s\n", kodr.ErrAllUsefulPiecesReceived)
+		}
+
+		d_pieces, err := dec.GetPieces()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		for i := range pieceCount {
+			if !bytes.Equal(pieces[i], d_pieces[i]) {
+				t.Fatal("decoded data doesn't match !")
+			}
+		}
+	}
+}
+
+func TestIncrementalFullRLNCRecoderMalformed(t *testing.T) {
+	var (
+		pieceCount  uint = 8
+		pieceLength uint = 64
+		enc              = full.NewFullRLNCEncoder(generatePieces(pieceCount, pieceLength))
+		rec              = full.NewIncrementalFullRLNCRecoder(pieceCount)
+	)
+
+	// linearly dependent piece isn't held, though it still fixes
+	// version, which all following pieces must be coded from
+	zero := &kodr_internals.CodedPiece{
+		Vector:  make(kodr_internals.CodingVector, pieceCount),
+		Piece:   make(kodr_internals.Piece, pieceLength),
+		Version: 1,
+	}
+	if err := rec.AddPiece(zero); err != nil {
+		t.Fatal(err.Error())
+	}
+	if rec.Rank() != 0 || rec.Received() != 1 {
+		t.Fatalf("expected rank 0 & 1 piece received, found %d & %d\n", rec.Rank(), rec.Received())
+	}
+	if err := rec.AddPiece(enc.CodedPiece()); !errors.Is(err, kodr.ErrVersionMismatch) {
+		t.Fatalf("expected: %s\n", kodr.ErrVersionMismatch)
+	}
+
+	c_piece := enc.CodedPiece()
+	c_piece.Version = 1
+	if err := rec.AddPiece(c_piece); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	short := enc.CodedPiece()
+	short.Version = 1
+	short.Piece = short.Piece[:pieceLength-1]
+	if err := rec.AddPiece(short); !errors.Is(err, kodr.ErrCodedDataLengthMismatch) {
+		t.Fatalf("expected: %s\n", kodr.ErrCodedDataLengthMismatch)
+	}
+	if rec.Rank() != 1 {
+		t.Fatalf("expected rank 1, found %d\n", rec.Rank())
+	}
+}
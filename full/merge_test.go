@@ -0,0 +1,98 @@
+package full_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/full"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/matrix"
+)
+
+func copyCodedPiece(c_piece *kodr_internals.CodedPiece) *kodr_internals.CodedPiece {
+	vector := make(kodr_internals.CodingVector, len(c_piece.Vector))
+	copy(vector, c_piece.Vector)
+	piece := make(kodr_internals.Piece, len(c_piece.Piece))
+	copy(piece, c_piece.Piece)
+	return &kodr_internals.CodedPiece{Vector: vector, Piece: piece, Field: c_piece.Field}
+}
+
+// Two partial decoders, sharing some pieces, are merged into one,
+// which must count every received piece, but only distinct ones as useful
+func TestFullRLNCDecoderMerge(t *testing.T) {
+	var (
+		pieceCount  uint = 128
+		pieceLength uint = 1024
+		pieces           = generatePieces(pieceCount, pieceLength)
+		enc              = full.NewFullRLNCEncoder(pieces)
+		dec              = full.NewFullRLNCDecoder(pieceCount)
+		other            = full.NewFullRLNCDecoder(pieceCount)
+	)
+
+	for i := range 40 {
+		c_piece := enc.CodedPiece()
+		if i < 10 {
+			if err := other.AddPiece(copyCodedPiece(c_piece)); err != nil {
+				t.Fatal(err.Error())
+			}
+		}
+		if err := dec.AddPiece(c_piece); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+	for range 50 {
+		if err := other.AddPiece(enc.CodedPiece()); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	if err := dec.Merge(other); err != nil {
+		t.Fatal(err.Error())
+	}
+	if dec.Rank() != 90 {
+		t.Fatalf("expected merged rank 90, found %d\n", dec.Rank())
+	}
+	if dec.Received() != 100 {
+		t.Fatalf("expected 100 received pieces, found %d\n", dec.Received())
+	}
+	if dec.Required() != pieceCount-90 {
+		t.Fatalf("expected %d more pieces required, found %d\n", pieceCount-90, dec.Required())
+	}
+	if other.Rank() != 60 {
+		t.Fatalf("merged decoder must be left untouched, found rank %d\n", other.Rank())
+	}
+
+	// merging same decoder again brings nothing useful
+	if err := dec.Merge(other); err != nil {
+		t.Fatal(err.Error())
+	}
+	if dec.Rank() != 90 {
+		t.Fatalf("expected merged rank 90, found %d\n", dec.Rank())
+	}
+
+	for !dec.IsDecoded() {
+		if err := dec.AddPiece(enc.CodedPiece()); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	d_pieces, err := dec.GetPieces()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	for i := range pieceCount {
+		if !bytes.Equal(pieces[i], d_pieces[i]) {
+			t.Fatal("decoded data doesn't match !")
+		}
+	}
+
+	if err := dec.Merge(full.NewFullRLNCDecoder(pieceCount - 1)); !errors.Is(err, kodr.ErrDecoderMismatch) {
+		t.Fatalf("expected: %s\n", kodr.ErrDecoderMismatch)
+	}
+	sparse := full.NewFullRLNCDecoderWithState(pieceCount, matrix.NewSparseDecoderStateWithPieceCount(pieceCount))
+	if err := dec.Merge(sparse); !errors.Is(err, kodr.ErrDecoderMismatch) {
+		t.Fatalf("expected: %s\n", kodr.ErrDecoderMismatch)
+	}
+}
@@ -1,21 +1,37 @@
 package full
 
 import (
+	"math/rand"
+
+	"github.com/itzmeanjan/kodr"
 	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf65536"
 	"github.com/itzmeanjan/kodr/kodr_internals/matrix"
 )
 
 type FullRLNCRecoder struct {
 	pieces       []*kodr_internals.CodedPiece
 	codingMatrix matrix.Matrix
+	field        field.Field
+	invalid      error
 }
 
 func (r *FullRLNCRecoder) fill() {
 	codingMatrix := make(matrix.Matrix, len(r.pieces))
-	
+
 	for i := range r.pieces {
 		codingMatrix[i] = make([]byte, len(r.pieces[i].Vector))
 		copy(codingMatrix[i], r.pieces[i].Vector)
+
+		// pieces coded over different field can't be recoded, while
+		// ones coded from different versions of object can't be
+		// combined, as recoded piece would belong to neither
+		if r.pieces[i].Field != 0 && r.pieces[i].Field != r.field.ID() {
+			r.invalid = kodr.ErrFieldMismatch
+		} else if r.invalid == nil && r.pieces[i].Version != r.pieces[0].Version {
+			r.invalid = kodr.ErrVersionMismatch
+		}
 	}
 
 	r.codingMatrix = codingMatrix
@@ -25,32 +41,151 @@ func (r *FullRLNCRecoder) fill() {
 // by randomly drawing some coding coefficients from
 // finite field & performing full RLNC with all coded pieces
 func (r *FullRLNCRecoder) CodedPiece() (*kodr_internals.CodedPiece, error) {
+	if r.invalid != nil {
+		return nil, r.invalid
+	}
+
 	pieceCount := uint(len(r.pieces))
-	vector := kodr_internals.GenerateCodingVector(pieceCount)
+	vector := r.field.RandomVector(pieceCount)
 	piece := make(kodr_internals.Piece, len(r.pieces[0].Piece))
-	
+
 	for i := range r.pieces {
-		piece.Multiply(r.pieces[i].Piece, vector[i])
+		r.field.MulAddSlice(piece, r.pieces[i].Piece, r.field.Symbol(vector, uint(i)))
 	}
 
 	vector_ := matrix.Matrix{vector}
-	mult, err := vector_.Multiply(r.codingMatrix)
+	mult, err := vector_.MultiplyWithField(r.field, r.codingMatrix)
 	if err != nil {
 		return nil, err
 	}
 
 	return &kodr_internals.CodedPiece{
-		Vector: mult[0],
-		Piece:  piece,
+		Vector:  mult[0],
+		Piece:   piece,
+		Field:   r.field.ID(),
+		Version: r.pieces[0].Version,
+	}, nil
+}
+
+// Returns recoded piece, which combines only `fanIn` many held pieces,
+// chosen uniformly at random, with random nonzero coefficients, so that
+// recoding cost doesn't grow with #-of held pieces, while recoded piece
+// stays as sparse as held ones are, which suits downstream sparse decoders
+//
+// If fan-in is >= #-of held pieces, all of them are combined
+func (r *FullRLNCRecoder) SparseCodedPiece(fanIn uint) (*kodr_internals.CodedPiece, error) {
+	if fanIn == 0 {
+		return nil, kodr.ErrBadFanIn
+	}
+	if r.invalid != nil {
+		return nil, r.invalid
+	}
+
+	held := len(r.pieces)
+	fanIn_ := min(int(fanIn), held)
+	chosen := make(map[int]struct{}, fanIn_)
+
+	vector := make(kodr_internals.CodingVector, r.codingMatrix.Cols())
+	piece := make(kodr_internals.Piece, len(r.pieces[0].Piece))
+	// Floyd's algorithm, for choosing `fanIn` distinct held pieces,
+	// in time proportional to fan-in, instead of #-of held pieces
+	for j := held - fanIn_; j < held; j++ {
+		i := rand.Intn(j + 1)
+		if _, ok := chosen[i]; ok {
+			i = j
+		}
+		chosen[i] = struct{}{}
+
+		w := r.field.Random()
+		for w == 0 {
+			w = r.field.Random()
+		}
+
+		r.field.MulAddSlice(vector, r.codingMatrix[i], w)
+		r.field.MulAddSlice(piece, r.pieces[i].Piece, w)
+	}
+
+	return &kodr_internals.CodedPiece{
+		Vector:  vector,
+		Piece:   piece,
+		Field:   r.field.ID(),
+		Version: r.pieces[0].Version,
+	}, nil
+}
+
+// Returns recoded piece, which is guaranteed to raise rank of decoder,
+// whose received subspace is summarized. Held pieces' coding vectors are
+// reduced against summary, then random combination is drawn as usual,
+// but if its residual turns out to be zero, weight of one held piece with
+// nonzero residual is bumped, which makes recoded piece innovative
+//
+// If no held piece is innovative for summarized decoder, returns error
+func (r *FullRLNCRecoder) CodedPieceFor(summary *matrix.Summary) (*kodr_internals.CodedPiece, error) {
+	if summary.Field().ID() != r.field.ID() || summary.PieceCount()*r.field.SymbolSize() != r.codingMatrix.Cols() {
+		return nil, kodr.ErrSummaryMismatch
+	}
+	if r.invalid != nil {
+		return nil, r.invalid
+	}
+
+	innovative := make([]uint, 0, len(r.pieces))
+	residuals := make([]kodr_internals.CodingVector, len(r.pieces))
+	for i := range r.codingMatrix {
+		residuals[i] = summary.Residual(r.codingMatrix[i])
+		if !isZero(residuals[i]) {
+			innovative = append(innovative, uint(i))
+		}
+	}
+	if len(innovative) == 0 {
+		return nil, kodr.ErrNoInnovativePiece
+	}
+
+	pieceCount := uint(len(r.pieces))
+	weights := r.field.RandomVector(pieceCount)
+
+	residual := make(kodr_internals.CodingVector, r.codingMatrix.Cols())
+	for i := range residuals {
+		r.field.MulAddSlice(residual, residuals[i], r.field.Symbol(weights, uint(i)))
+	}
+	if isZero(residual) {
+		i := innovative[rand.Intn(len(innovative))]
+		r.field.SetSymbol(weights, i, r.field.Add(r.field.Symbol(weights, i), 1))
+	}
+
+	vector := make(kodr_internals.CodingVector, r.codingMatrix.Cols())
+	piece := make(kodr_internals.Piece, len(r.pieces[0].Piece))
+	for i := range r.pieces {
+		w := r.field.Symbol(weights, uint(i))
+		r.field.MulAddSlice(vector, r.codingMatrix[i], w)
+		r.field.MulAddSlice(piece, r.pieces[i].Piece, w)
+	}
+
+	return &kodr_internals.CodedPiece{
+		Vector:  vector,
+		Piece:   piece,
+		Field:   r.field.ID(),
+		Version: r.pieces[0].Version,
 	}, nil
 }
 
+func isZero(vector kodr_internals.CodingVector) bool {
+	for _, b := range vector {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
 // Provide with all coded pieces, which are to be used
 // for performing fullRLNC ( read recoding of coded data )
 // & get back recoder which is used for on-the-fly construction
 // of N-many recoded pieces
+//
+// All pieces must be coded over recoder's field, from same version of
+// object, otherwise recoding returns error
 func NewFullRLNCRecoder(pieces []*kodr_internals.CodedPiece) *FullRLNCRecoder {
-	rec := &FullRLNCRecoder{pieces: pieces}
+	rec := &FullRLNCRecoder{pieces: pieces, field: field.Default()}
 	rec.fill()
 
 	return rec
@@ -68,3 +203,37 @@ func NewFullRLNCRecoderWithFlattenData(data []byte, pieceCount uint, piecesCoded
 
 	return NewFullRLNCRecoder(codedPieces), nil
 }
+
+// Same as `NewFullRLNCRecoder`, but for recoding pieces, which
+// were coded over given finite field
+func NewFullRLNCRecoderWithField(pieces []*kodr_internals.CodedPiece, f field.Field) *FullRLNCRecoder {
+	rec := &FullRLNCRecoder{pieces: pieces, field: f}
+	rec.fill()
+
+	return rec
+}
+
+// Same as `NewFullRLNCRecoderWithFlattenData`, but each coded piece's
+// coding vector holds `piecesCodedTogether` many coefficients of given
+// finite field i.e. it's `f.SymbolSize() * piecesCodedTogether` bytes long
+func NewFullRLNCRecoderWithFieldAndFlattenData(data []byte, pieceCount uint, piecesCodedTogether uint, f field.Field) (*FullRLNCRecoder, error) {
+	codedPieces, err := kodr_internals.CodedPiecesForRecoding(data, pieceCount, piecesCodedTogether*f.SymbolSize())
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFullRLNCRecoderWithField(codedPieces, f), nil
+}
+
+// Same as `NewFullRLNCRecoder`, but for recoding pieces, which
+// were coded over GF(2^16)
+func NewFullRLNCRecoderGf65536(pieces []*kodr_internals.CodedPiece) *FullRLNCRecoder {
+	return NewFullRLNCRecoderWithField(pieces, gf65536.DefaultField())
+}
+
+// Same as `NewFullRLNCRecoderWithFlattenData`, but each coded piece's
+// coding vector holds `piecesCodedTogether` many GF(2^16) coefficients
+// i.e. it's 2 * piecesCodedTogether bytes long
+func NewFullRLNCRecoderGf65536WithFlattenData(data []byte, pieceCount uint, piecesCodedTogether uint) (*FullRLNCRecoder, error) {
+	return NewFullRLNCRecoderWithFieldAndFlattenData(data, pieceCount, piecesCodedTogether, gf65536.DefaultField())
+}
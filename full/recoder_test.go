@@ -3,11 +3,13 @@ package full_test
 import (
 	"bytes"
 	"errors"
+	"math/rand"
 	"testing"
 
 	"github.com/itzmeanjan/kodr"
 	"github.com/itzmeanjan/kodr/full"
 	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/matrix"
 )
 
 func recoderFlow(t *testing.T, rec *full.FullRLNCRecoder, pieceCount int, pieces []kodr_internals.Piece) {
@@ -78,3 +80,68 @@ func TestNewFullRLNCRecoderWithFlattenData(t *testing.T) {
 
 	recoderFlow(t, rec, pieceCount, pieces)
 }
+
+// Held pieces carry at most 2 nonzero coefficients, so recoded ones, with
+// fan-in 4, must carry at most 8, while sparse decoder state still decodes
+func TestFullRLNCRecoderSparseCodedPiece(t *testing.T) {
+	var (
+		pieceCount  uint = 128
+		pieceLength uint = 64
+		fanIn       uint = 4
+		pieces           = generatePieces(pieceCount, pieceLength)
+	)
+
+	vectors := make([]kodr_internals.CodingVector, 0, 2*pieceCount)
+	for i := range pieceCount {
+		vector := make(kodr_internals.CodingVector, pieceCount)
+		vector[i] = 1
+		vectors = append(vectors, vector)
+	}
+	for range pieceCount {
+		vector := make(kodr_internals.CodingVector, pieceCount)
+		for _, i := range rand.Perm(int(pieceCount))[:2] {
+			vector[i] = byte(rand.Intn(255) + 1)
+		}
+		vectors = append(vectors, vector)
+	}
+
+	rec := full.NewFullRLNCRecoder(codeWithVectors(pieces, vectors))
+	dec := full.NewFullRLNCDecoderWithState(pieceCount, matrix.NewSparseDecoderStateWithPieceCount(pieceCount))
+
+	for range 16 * pieceCount {
+		r_piece, err := rec.SparseCodedPiece(fanIn)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		nonZero := uint(0)
+		for _, c := range r_piece.Vector {
+			if c != 0 {
+				nonZero++
+			}
+		}
+		if nonZero > 2*fanIn {
+			t.Fatalf("expected at most %d nonzero coefficients, found %d\n", 2*fanIn, nonZero)
+		}
+
+		if err := dec.AddPiece(r_piece); errors.Is(err, kodr.ErrAllUsefulPiecesReceived) {
+			break
+		} else if err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	d_pieces, err := dec.GetPieces()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	for i := range pieceCount {
+		if !bytes.Equal(pieces[i], d_pieces[i]) {
+			t.Fatal("decoded data doesn't match !")
+		}
+	}
+
+	if _, err := rec.SparseCodedPiece(0); !errors.Is(err, kodr.ErrBadFanIn) {
+		t.Fatalf("expected: %s\n", kodr.ErrBadFanIn)
+	}
+}
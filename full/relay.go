@@ -0,0 +1,168 @@
+package full
+
+import (
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf65536"
+	"github.com/itzmeanjan/kodr/kodr_internals/matrix"
+)
+
+// Intermediate node of mesh, which both consumes & forwards coded
+// pieces, decoding those progressively, while recoding from its current
+// RREF basis, so that every piece is stored only once. As soon as it's
+// fully decoded, it becomes a seeder i.e. it codes original pieces, just
+// like source does
+type FullRLNCRelay struct {
+	expected, received uint
+	version            uint64
+	state              *matrix.DecoderState
+	encoder            *FullRLNCEncoder
+}
+
+// IsDecoded - Whether all original pieces are decoded, after which
+// relay acts as source encoder
+func (r *FullRLNCRelay) IsDecoded() bool {
+	return r.state.Rank() >= r.expected
+}
+
+// Required - How many more linearly independent pieces
+// are required for successfully decoding pieces ?
+func (r *FullRLNCRelay) Required() uint {
+	return r.expected - r.state.Rank()
+}
+
+// Rank - How many linearly independent pieces are received so far
+func (r *FullRLNCRelay) Rank() uint {
+	return r.state.Rank()
+}
+
+// Received - How many coded pieces are received so far, including
+// linearly dependent ones
+func (r *FullRLNCRelay) Received() uint {
+	return r.received
+}
+
+// PieceLength - Returns piece length in bytes
+//
+// If no pieces are yet added to relay, then
+// returns 0, denoting **unknown**
+func (r *FullRLNCRelay) PieceLength() uint {
+	return r.state.PieceLength()
+}
+
+// AddPiece - Adds a new received coded piece, which is reduced against
+// basis held so far & dropped, if it's linearly dependent. Once all
+// original pieces are decoded, relay switches to acting as source encoder.
+// All pieces must be coded from same version of object, as first one received
+//
+// Note: Coded piece is copied, caller's slices are never modified
+func (r *FullRLNCRelay) AddPiece(piece *kodr_internals.CodedPiece) error {
+	if r.IsDecoded() {
+		return kodr.ErrAllUsefulPiecesReceived
+	}
+	if piece.Field != 0 && piece.Field != r.state.Field().ID() {
+		return kodr.ErrFieldMismatch
+	}
+	if uint(len(piece.Vector)) != r.expected*r.state.Field().SymbolSize() {
+		return kodr.ErrCodingVectorLengthMismatch
+	}
+	if l := r.PieceLength(); l != 0 && uint(len(piece.Piece)) != l {
+		return kodr.ErrCodedDataLengthMismatch
+	}
+	if r.received > 0 && piece.Version != r.version {
+		return kodr.ErrVersionMismatch
+	}
+	r.version = piece.Version
+
+	vector := make(kodr_internals.CodingVector, len(piece.Vector))
+	copy(vector, piece.Vector)
+	c_piece := make(kodr_internals.Piece, len(piece.Piece))
+	copy(c_piece, piece.Piece)
+
+	r.state.AddPiece(&kodr_internals.CodedPiece{Vector: vector, Piece: c_piece})
+	r.received++
+	r.state.Rref()
+
+	if !r.IsDecoded() {
+		return nil
+	}
+
+	pieces, err := r.GetPieces()
+	if err != nil {
+		return err
+	}
+	enc, err := NewFullRLNCEncoderWithField(pieces, r.state.Field())
+	if err != nil {
+		return err
+	}
+	enc.version = r.version
+	r.encoder = enc
+	return nil
+}
+
+// CodedPiece - Returns coded piece to be forwarded, which is recoded
+// from current basis, while being decoded, or coded from original pieces,
+// once fully decoded. It can be invoked any time
+//
+// If no piece is received yet, returns error
+func (r *FullRLNCRelay) CodedPiece() (*kodr_internals.CodedPiece, error) {
+	if r.encoder != nil {
+		return r.encoder.CodedPiece(), nil
+	}
+	return recodeBasis(r.state, r.version)
+}
+
+// Decodable - Indices of original pieces, which are decodable right now,
+// in increasing order
+func (r *FullRLNCRelay) Decodable() []uint {
+	return r.state.Decodable()
+}
+
+// GetPiece - Get a decoded piece by index, may ( not ) succeed !
+func (r *FullRLNCRelay) GetPiece(i uint) (kodr_internals.Piece, error) {
+	return r.state.GetPiece(i)
+}
+
+// GetPieces - Get a list of all decoded pieces, given full
+// decoding has happened
+func (r *FullRLNCRelay) GetPieces() ([]kodr_internals.Piece, error) {
+	if !r.IsDecoded() {
+		return nil, kodr.ErrMoreUsefulPiecesRequired
+	}
+
+	pieces := make([]kodr_internals.Piece, 0, r.expected)
+	for i := range r.expected {
+		piece, err := r.GetPiece(i)
+		if err != nil {
+			return nil, err
+		}
+		pieces = append(pieces, piece)
+	}
+	return pieces, nil
+}
+
+// Summary - Compact summary of subspace spanned by received coding
+// vectors, which can be sent to peers, so that they craft coded
+// pieces guaranteed to be innovative for this relay
+func (r *FullRLNCRelay) Summary() *matrix.Summary {
+	return r.state.Summary()
+}
+
+// Relay for pieces coded together with `pieceCount` many others, which
+// decodes those, while forwarding recoded pieces any time
+func NewFullRLNCRelay(pieceCount uint) *FullRLNCRelay {
+	return NewFullRLNCRelayWithField(pieceCount, field.Default())
+}
+
+// Same as `NewFullRLNCRelay`, but for pieces coded ( or recoded )
+// over given finite field
+func NewFullRLNCRelayWithField(pieceCount uint, f field.Field) *FullRLNCRelay {
+	return &FullRLNCRelay{expected: pieceCount, state: matrix.NewDecoderStateWithField(pieceCount, f)}
+}
+
+// Same as `NewFullRLNCRelay`, but for pieces coded ( or recoded )
+// over GF(2^16)
+func NewFullRLNCRelayGf65536(pieceCount uint) *FullRLNCRelay {
+	return NewFullRLNCRelayWithField(pieceCount, gf65536.DefaultField())
+}
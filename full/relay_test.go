@@ -0,0 +1,117 @@
+package full_test
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/full"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+)
+
+// Source -> relay -> sink, over lossy links, where relay forwards
+// recoded pieces while still decoding, then seeds once it's done,
+// so that sink decodes, even after source goes away
+func TestFullRLNCRelay(t *testing.T) {
+	var (
+		pieceCount  uint = 64
+		pieceLength uint = 256
+		pieces           = generatePieces(pieceCount, pieceLength)
+		enc              = full.NewFullRLNCEncoder(pieces)
+		relay            = full.NewFullRLNCRelay(pieceCount)
+		dec              = full.NewFullRLNCDecoder(pieceCount)
+	)
+
+	if _, err := relay.CodedPiece(); !errors.Is(err, kodr.ErrNoInnovativePiece) {
+		t.Fatalf("expected: %s\n", kodr.ErrNoInnovativePiece)
+	}
+
+	for !relay.IsDecoded() {
+		if rand.Intn(4) != 0 {
+			if err := relay.AddPiece(enc.CodedPiece()); err != nil {
+				t.Fatal(err.Error())
+			}
+		}
+
+		r_piece, err := relay.CodedPiece()
+		if errors.Is(err, kodr.ErrNoInnovativePiece) {
+			continue
+		}
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if rand.Intn(2) == 0 {
+			continue
+		}
+		if err := dec.AddPiece(r_piece); err != nil {
+			t.Fatal(err.Error())
+		}
+		if dec.Rank() > relay.Rank() {
+			t.Fatalf("decoder rank %d > relay rank %d\n", dec.Rank(), relay.Rank())
+		}
+	}
+
+	if err := relay.AddPiece(enc.CodedPiece()); !errors.Is(err, kodr.ErrAllUsefulPiecesReceived) {
+		t.Fatalf("expected: %s\n", kodr.ErrAllUsefulPiecesReceived)
+	}
+
+	// source is gone, relay seeds
+	for !dec.IsDecoded() {
+		r_piece, err := relay.CodedPiece()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if err := dec.AddPiece(r_piece); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	r_pieces, err := relay.GetPieces()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	d_pieces, err := dec.GetPieces()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	for i := range pieceCount {
+		if !bytes.Equal(pieces[i], r_pieces[i]) || !bytes.Equal(pieces[i], d_pieces[i]) {
+			t.Fatal("decoded data doesn't match !")
+		}
+	}
+}
+
+func TestFullRLNCRelayMalformed(t *testing.T) {
+	var (
+		pieceCount  uint = 8
+		pieceLength uint = 64
+		enc              = full.NewFullRLNCEncoder(generatePieces(pieceCount, pieceLength))
+		relay            = full.NewFullRLNCRelay(pieceCount)
+	)
+
+	c_piece := enc.CodedPiece()
+	if err := relay.AddPiece(c_piece); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	short := enc.CodedPiece()
+	short.Piece = short.Piece[:pieceLength-1]
+	if err := relay.AddPiece(short); !errors.Is(err, kodr.ErrCodedDataLengthMismatch) {
+		t.Fatalf("expected: %s\n", kodr.ErrCodedDataLengthMismatch)
+	}
+
+	stale := enc.CodedPiece()
+	stale.Version = 1
+	if err := relay.AddPiece(stale); !errors.Is(err, kodr.ErrVersionMismatch) {
+		t.Fatalf("expected: %s\n", kodr.ErrVersionMismatch)
+	}
+
+	if err := relay.AddPiece(&kodr_internals.CodedPiece{Vector: c_piece.Vector, Piece: c_piece.Piece}); err != nil {
+		t.Fatal(err.Error())
+	}
+	if relay.Rank() != 1 || relay.Received() != 2 {
+		t.Fatalf("expected rank 1 & 2 pieces received, found %d & %d\n", relay.Rank(), relay.Received())
+	}
+}
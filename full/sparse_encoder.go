@@ -0,0 +1,129 @@
+package full
+
+import (
+	"encoding/binary"
+	"math/rand"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+)
+
+// Decides how dense coding vectors need to be, given how many
+// linearly independent pieces receiver has already collected
+//
+// Returned value is the probability of each coding coefficient being
+// non-zero, expected to be in (0, 1], where 1 means full RLNC
+type DensityPolicy interface {
+	Density(rank, pieceCount uint) float64
+}
+
+// Same density, irrespective of receiver's progress
+type FixedDensity float64
+
+func (f FixedDensity) Density(rank, pieceCount uint) float64 {
+	return float64(f)
+}
+
+// Keeps on average `Scale * N / ( N - rank )` non-zero coefficients,
+// but never fewer than `Minimum` fraction of N, so that coding vectors
+// are very sparse at the start, when almost any piece is innovative,
+// while they become dense as rank approaches N, when a sparse piece
+// is likely to be linearly dependent on already received ones
+type AdaptiveDensity struct {
+	Scale   float64
+	Minimum float64
+}
+
+func (a AdaptiveDensity) Density(rank, pieceCount uint) float64 {
+	if rank >= pieceCount {
+		return 1
+	}
+	return min(1, max(a.Minimum, a.Scale/float64(pieceCount-rank)))
+}
+
+// Sparse variant of full RLNC encoder, where density of coding vectors
+// is chosen by pluggable policy, based on rank receiver last reported
+type SparseFullRLNCEncoder struct {
+	*FullRLNCEncoder
+	policy DensityPolicy
+	rank   uint
+}
+
+// Updates receiver's rank, as carried in feedback, so that
+// next coded pieces are generated with density suitable for it
+func (s *SparseFullRLNCEncoder) SetReceiverRank(rank uint) {
+	s.rank = min(rank, s.PieceCount())
+}
+
+// Same as `SetReceiverRank`, but takes feedback, as serialized
+// by decoder's `Feedback` method
+func (s *SparseFullRLNCEncoder) ApplyFeedback(feedback []byte) error {
+	rank, n := binary.Uvarint(feedback)
+	if n <= 0 || n != len(feedback) {
+		return kodr.ErrMalformedFeedback
+	}
+
+	s.SetReceiverRank(uint(rank))
+	return nil
+}
+
+// Current density of coding vectors i.e. probability of each
+// coefficient being non-zero
+func (s *SparseFullRLNCEncoder) Density() float64 {
+	return s.policy.Density(s.rank, s.PieceCount())
+}
+
+// Returns a coded piece, where each original piece is included with
+// probability of current density, though at least one piece is always
+// included, so that coding vector is never zero
+func (s *SparseFullRLNCEncoder) CodedPiece() *kodr_internals.CodedPiece {
+	var (
+		pieceCount = s.PieceCount()
+		density    = s.Density()
+		f          = s.field
+	)
+
+	vector := make(kodr_internals.CodingVector, pieceCount*f.SymbolSize())
+	piece := make(kodr_internals.Piece, s.PieceSize())
+
+	nonZero := func() uint32 {
+		for {
+			if c := f.Random(); c != 0 {
+				return c
+			}
+		}
+	}
+
+	included := 0
+	for i := range pieceCount {
+		if rand.Float64() >= density {
+			continue
+		}
+
+		c := nonZero()
+		f.SetSymbol(vector, i, c)
+		f.MulAddSlice(piece, s.pieces[i], c)
+		included++
+	}
+
+	if included == 0 {
+		i := uint(rand.Intn(int(pieceCount)))
+		c := nonZero()
+		f.SetSymbol(vector, i, c)
+		f.MulAddSlice(piece, s.pieces[i], c)
+	}
+
+	return &kodr_internals.CodedPiece{
+		Vector:  vector,
+		Piece:   piece,
+		Field:   f.ID(),
+		Version: s.version,
+	}
+}
+
+// Wraps full RLNC encoder, so that it produces sparse coded pieces,
+// whose density is decided by given policy, assuming receiver hasn't
+// yet collected any piece
+func NewSparseFullRLNCEncoder(enc *FullRLNCEncoder, policy DensityPolicy) *SparseFullRLNCEncoder {
+	return &SparseFullRLNCEncoder{FullRLNCEncoder: enc, policy: policy}
+}
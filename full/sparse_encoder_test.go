@@ -0,0 +1,148 @@
+package full_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/full"
+	"github.com/itzmeanjan/kodr/kodr_internals/matrix"
+)
+
+// Feeds sparse coded pieces to decoder, while reporting decoder's rank
+// back to encoder after every piece, returns #-of coded pieces received
+// & #-of non-zero coding coefficients, those carried in total
+func sparseFlow(t *testing.T, enc *full.SparseFullRLNCEncoder, adapt bool) (uint, uint) {
+	dec := full.NewFullRLNCDecoder(enc.PieceCount())
+	received, nonZero := uint(0), uint(0)
+
+	for !dec.IsDecoded() {
+		c_piece := enc.CodedPiece()
+		for _, c := range c_piece.Vector {
+			if c != 0 {
+				nonZero++
+			}
+		}
+
+		if err := dec.AddPiece(c_piece); err != nil {
+			t.Fatal(err.Error())
+		}
+		received++
+
+		if adapt {
+			if err := enc.ApplyFeedback(dec.Feedback()); err != nil {
+				t.Fatal(err.Error())
+			}
+		}
+	}
+
+	return received, nonZero
+}
+
+func TestSparseFullRLNCEncoder(t *testing.T) {
+	var (
+		pieceCount  uint = 64
+		pieceLength uint = 256
+		rounds           = 8
+		adaptive         = full.AdaptiveDensity{Scale: 2}
+	)
+
+	if d := adaptive.Density(0, pieceCount); d != 2/float64(pieceCount) {
+		t.Fatalf("expected density %f at start, found %f\n", 2/float64(pieceCount), d)
+	}
+	if d := adaptive.Density(pieceCount-1, pieceCount); d != 1 {
+		t.Fatalf("expected dense coding vectors near end, found density %f\n", d)
+	}
+
+	enc := full.NewSparseFullRLNCEncoder(full.NewFullRLNCEncoder(generatePieces(pieceCount, pieceLength)), adaptive)
+	if err := enc.ApplyFeedback([]byte{0xff}); !errors.Is(err, kodr.ErrMalformedFeedback) {
+		t.Fatalf("expected: %s\n", kodr.ErrMalformedFeedback)
+	}
+
+	t.Run("Decode", func(t *testing.T) {
+		pieces := generatePieces(pieceCount, pieceLength)
+		enc := full.NewSparseFullRLNCEncoder(full.NewFullRLNCEncoder(pieces), adaptive)
+
+		dec := full.NewFullRLNCDecoder(pieceCount)
+		for !dec.IsDecoded() {
+			if err := dec.AddPiece(enc.CodedPiece()); err != nil {
+				t.Fatal(err.Error())
+			}
+			enc.SetReceiverRank(dec.Rank())
+		}
+
+		d_pieces, err := dec.GetPieces()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		for i := range pieces {
+			if !bytes.Equal(pieces[i], d_pieces[i]) {
+				t.Fatal("decoded data doesn't match !")
+			}
+		}
+	})
+
+	// Adapting density must save pieces compared to staying sparse,
+	// while carrying fewer non-zero coefficients than full RLNC
+	t.Run("Overhead", func(t *testing.T) {
+		var adaptiveReceived, adaptiveNonZero, fixedReceived, denseNonZero uint
+
+		for range rounds {
+			pieces := generatePieces(pieceCount, pieceLength)
+
+			received, nonZero := sparseFlow(t, full.NewSparseFullRLNCEncoder(full.NewFullRLNCEncoder(pieces), adaptive), true)
+			adaptiveReceived += received
+			adaptiveNonZero += nonZero
+
+			received, _ = sparseFlow(t, full.NewSparseFullRLNCEncoder(full.NewFullRLNCEncoder(pieces), full.FixedDensity(2/float64(pieceCount))), false)
+			fixedReceived += received
+
+			_, nonZero = sparseFlow(t, full.NewSparseFullRLNCEncoder(full.NewFullRLNCEncoder(pieces), full.FixedDensity(1)), false)
+			denseNonZero += nonZero
+		}
+
+		if adaptiveReceived >= fixedReceived {
+			t.Fatalf("adaptive density took %d pieces, fixed sparse density took %d\n", adaptiveReceived, fixedReceived)
+		}
+		if adaptiveNonZero >= denseNonZero {
+			t.Fatalf("adaptive density carried %d non-zero coefficients, full RLNC carried %d\n", adaptiveNonZero, denseNonZero)
+		}
+	})
+}
+
+// Sparse coded pieces are decoded using sparse decoder state too, which
+// needs no more pieces than dense one
+func TestFullRLNCDecoderWithSparseState(t *testing.T) {
+	var (
+		pieceCount  uint = 256
+		pieceLength uint = 256
+		pieces           = generatePieces(pieceCount, pieceLength)
+		enc              = full.NewSparseFullRLNCEncoder(full.NewFullRLNCEncoder(pieces), full.FixedDensity(4/float64(pieceCount)))
+		state            = matrix.NewSparseDecoderStateWithPieceCount(pieceCount)
+		dec              = full.NewFullRLNCDecoderWithState(pieceCount, state)
+	)
+
+	for !dec.IsDecoded() {
+		if err := dec.AddPiece(enc.CodedPiece()); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	if dec.PieceLength() != pieceLength {
+		t.Fatalf("expected piece length %dB, found %dB\n", pieceLength, dec.PieceLength())
+	}
+	if state.Inactivated() >= pieceCount/2 {
+		t.Fatalf("%d columns inactivated, expected < %d\n", state.Inactivated(), pieceCount/2)
+	}
+
+	d_pieces, err := dec.GetPieces()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	for i := range pieces {
+		if !bytes.Equal(pieces[i], d_pieces[i]) {
+			t.Fatal("decoded data doesn't match !")
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package full_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/full"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf65536"
+)
+
+// Every coded piece crafted for decoder's summary must raise its rank,
+// so decoding needs exactly N pieces, even over tiny generations, where
+// blind random coding often sends linearly dependent ones
+func TestFullRLNCEncoderCodedPieceFor(t *testing.T) {
+	for _, f := range []field.Field{field.Default(), gf65536.DefaultField()} {
+		var (
+			pieceCount  uint = 32
+			pieceLength uint = 64
+			pieces           = generatePieces(pieceCount, pieceLength)
+		)
+
+		enc, err := full.NewFullRLNCEncoderWithField(pieces, f)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		dec := full.NewFullRLNCDecoderWithField(pieceCount, f)
+
+		for i := range pieceCount {
+			c_piece, err := enc.CodedPieceFor(dec.Summary())
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if err := dec.AddPiece(c_piece); err != nil {
+				t.Fatal(err.Error())
+			}
+			if dec.Rank() != i+1 {
+				t.Fatalf("expected rank %d, found %d\n", i+1, dec.Rank())
+			}
+		}
+
+		d_pieces, err := dec.GetPieces()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		for i := range pieces {
+			if !bytes.Equal(pieces[i], d_pieces[i]) {
+				t.Fatal("decoded data doesn't match !")
+			}
+		}
+
+		if _, err := enc.CodedPieceFor(dec.Summary()); !errors.Is(err, kodr.ErrAllUsefulPiecesReceived) {
+			t.Fatalf("expected: %s\n", kodr.ErrAllUsefulPiecesReceived)
+		}
+	}
+
+	enc := full.NewFullRLNCEncoder(generatePieces(8, 8))
+	if _, err := enc.CodedPieceFor(full.NewFullRLNCDecoder(9).Summary()); !errors.Is(err, kodr.ErrSummaryMismatch) {
+		t.Fatalf("expected: %s\n", kodr.ErrSummaryMismatch)
+	}
+}
+
+// Recoder holding only a few pieces must craft innovative recoded pieces
+// while it has something new for receiver, after which it must say so
+func TestFullRLNCRecoderCodedPieceFor(t *testing.T) {
+	var (
+		pieceCount  uint = 16
+		pieceLength uint = 64
+		pieces           = generatePieces(pieceCount, pieceLength)
+		enc              = full.NewFullRLNCEncoder(pieces)
+		dec              = full.NewFullRLNCDecoder(pieceCount)
+		held             = make([]*kodr_internals.CodedPiece, 0, 6)
+	)
+
+	for range 6 {
+		held = append(held, enc.CodedPiece())
+	}
+	rec := full.NewFullRLNCRecoder(held)
+
+	// receiver already has 3 of the pieces, recoder holds
+	for _, c_piece := range held[:3] {
+		copied := &kodr_internals.CodedPiece{
+			Vector: append(kodr_internals.CodingVector(nil), c_piece.Vector...),
+			Piece:  append(kodr_internals.Piece(nil), c_piece.Piece...),
+		}
+		if err := dec.AddPiece(copied); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	for i := range uint(3) {
+		r_piece, err := rec.CodedPieceFor(dec.Summary())
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if err := dec.AddPiece(r_piece); err != nil {
+			t.Fatal(err.Error())
+		}
+		if dec.Rank() != 4+i {
+			t.Fatalf("expected rank %d, found %d\n", 4+i, dec.Rank())
+		}
+	}
+
+	if _, err := rec.CodedPieceFor(dec.Summary()); !errors.Is(err, kodr.ErrNoInnovativePiece) {
+		t.Fatalf("expected: %s\n", kodr.ErrNoInnovativePiece)
+	}
+}
@@ -0,0 +1,113 @@
+package kodr_internals
+
+import (
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+)
+
+// Checks whether coded piece can be operated on, over given finite field
+// i.e. it's coded over same field & both of its components hold whole symbols
+func (c *CodedPiece) validate(f field.Field) error {
+	if c.Field != 0 && c.Field != f.ID() {
+		return kodr.ErrFieldMismatch
+	}
+	if uint(len(c.Vector))%f.SymbolSize() != 0 {
+		return kodr.ErrCodingVectorLengthMismatch
+	}
+	if uint(len(c.Piece))%f.SymbolSize() != 0 {
+		return kodr.ErrPieceSizeNotMultipleOfSymbolSize
+	}
+	return nil
+}
+
+// Checks whether other coded piece can be added to this one
+func (c *CodedPiece) compatible(other *CodedPiece) error {
+	if len(c.Vector) != len(other.Vector) {
+		return kodr.ErrCodingVectorLengthMismatch
+	}
+	if len(c.Piece) != len(other.Piece) {
+		return kodr.ErrCodedDataLengthMismatch
+	}
+	if c.Version != other.Version {
+		return kodr.ErrVersionMismatch
+	}
+	return nil
+}
+
+// Checks whether coefficient is an element of given finite field, by
+// serializing it as a symbol, which doesn't keep bits beyond field's width
+func inField(coeff uint32, f field.Field) error {
+	buf := make([]byte, f.SymbolSize())
+	f.SetSymbol(buf, 0, coeff)
+	if f.Symbol(buf, 0) != coeff {
+		return kodr.ErrCoefficientOutOfField
+	}
+	return nil
+}
+
+// Scale - Multiplies both coding vector & piece by given element of
+// finite field, in-place, so that it stays a valid coded piece
+func (c *CodedPiece) Scale(coeff uint32, f field.Field) error {
+	if err := inField(coeff, f); err != nil {
+		return err
+	}
+	if err := c.validate(f); err != nil {
+		return err
+	}
+
+	f.MulSlice(c.Vector, coeff)
+	f.MulSlice(c.Piece, coeff)
+	return nil
+}
+
+// AddScaled - Adds other coded piece, after multiplying it by given
+// element of finite field, to this one, in-place i.e. c += coeff × other,
+// applied to both coding vector & piece, while other one is left untouched
+//
+// Both coded pieces must be of same dimensions & version of object
+func (c *CodedPiece) AddScaled(other *CodedPiece, coeff uint32, f field.Field) error {
+	if err := inField(coeff, f); err != nil {
+		return err
+	}
+	if err := c.validate(f); err != nil {
+		return err
+	}
+	if err := other.validate(f); err != nil {
+		return err
+	}
+	if err := c.compatible(other); err != nil {
+		return err
+	}
+
+	f.MulAddSlice(c.Vector, other.Vector, coeff)
+	f.MulAddSlice(c.Piece, other.Piece, coeff)
+	return nil
+}
+
+// LinearCombination - Returns new coded piece, Σ coeffs[i] × pieces[i],
+// applied to both coding vector & piece, which is how recoding works,
+// so that custom recoding strategies can be built on top of it
+//
+// All coded pieces must be of same dimensions & version of object, while
+// exactly one coefficient is expected per coded piece
+func LinearCombination(pieces []*CodedPiece, coeffs []uint32, f field.Field) (*CodedPiece, error) {
+	if len(pieces) == 0 {
+		return nil, kodr.ErrEmptyCombination
+	}
+	if len(pieces) != len(coeffs) {
+		return nil, kodr.ErrCoefficientCountMismatch
+	}
+
+	res := &CodedPiece{
+		Vector:  make(CodingVector, len(pieces[0].Vector)),
+		Piece:   make(Piece, len(pieces[0].Piece)),
+		Field:   f.ID(),
+		Version: pieces[0].Version,
+	}
+	for i := range pieces {
+		if err := res.AddScaled(pieces[i], coeffs[i], f); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
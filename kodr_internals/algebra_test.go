@@ -0,0 +1,147 @@
+package kodr_internals_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/full"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf65536"
+)
+
+func copyCodedPiece(c *kodr_internals.CodedPiece) *kodr_internals.CodedPiece {
+	vector := make(kodr_internals.CodingVector, len(c.Vector))
+	copy(vector, c.Vector)
+	piece := make(kodr_internals.Piece, len(c.Piece))
+	copy(piece, c.Piece)
+	return &kodr_internals.CodedPiece{Vector: vector, Piece: piece, Field: c.Field, Version: c.Version}
+}
+
+// Custom recoding, built using linear combinations of coded pieces,
+// must still decode, while scaling & adding must be invertible
+func TestCodedPieceAlgebra(t *testing.T) {
+	for _, f := range []field.Field{field.Default(), gf65536.DefaultField()} {
+		var (
+			pieceCount  uint = 16
+			pieceLength uint = 64
+		)
+
+		pieces, _, err := kodr_internals.OriginalPiecesFromDataAndPieceCountWithSymbolSize(generateData(pieceCount*pieceLength), pieceCount, f.SymbolSize())
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		enc, err := full.NewFullRLNCEncoderWithField(pieces, f)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		held := make([]*kodr_internals.CodedPiece, 0, pieceCount)
+		for range pieceCount {
+			held = append(held, enc.CodedPiece())
+		}
+
+		dec := full.NewFullRLNCDecoderWithField(pieceCount, f)
+		for !dec.IsDecoded() {
+			coeffs := make([]uint32, len(held))
+			for i := range coeffs {
+				coeffs[i] = f.Random()
+			}
+
+			r_piece, err := kodr_internals.LinearCombination(held, coeffs, f)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if err := dec.AddPiece(r_piece); err != nil {
+				t.Fatal(err.Error())
+			}
+		}
+
+		d_pieces, err := dec.GetPieces()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		for i := range pieceCount {
+			if !bytes.Equal(pieces[i], d_pieces[i]) {
+				t.Fatal("decoded data doesn't match !")
+			}
+		}
+
+		c := copyCodedPiece(held[0])
+		coeff := f.Random()
+		for coeff == 0 {
+			coeff = f.Random()
+		}
+		inv, _ := f.Inv(coeff)
+
+		if err := c.Scale(coeff, f); err != nil {
+			t.Fatal(err.Error())
+		}
+		if err := c.AddScaled(held[1], coeff, f); err != nil {
+			t.Fatal(err.Error())
+		}
+		if err := c.AddScaled(held[1], f.Sub(0, coeff), f); err != nil {
+			t.Fatal(err.Error())
+		}
+		if err := c.Scale(inv, f); err != nil {
+			t.Fatal(err.Error())
+		}
+		if !bytes.Equal(c.Vector, held[0].Vector) || !bytes.Equal(c.Piece, held[0].Piece) {
+			t.Fatal("scaling & adding isn't invertible !")
+		}
+	}
+}
+
+func TestCodedPieceAlgebraErrors(t *testing.T) {
+	f := field.Default()
+	a := &kodr_internals.CodedPiece{Vector: make(kodr_internals.CodingVector, 4), Piece: make(kodr_internals.Piece, 8)}
+
+	for _, tc := range []struct {
+		other    *kodr_internals.CodedPiece
+		expected error
+	}{
+		{&kodr_internals.CodedPiece{Vector: make(kodr_internals.CodingVector, 5), Piece: make(kodr_internals.Piece, 8)}, kodr.ErrCodingVectorLengthMismatch},
+		{&kodr_internals.CodedPiece{Vector: make(kodr_internals.CodingVector, 4), Piece: make(kodr_internals.Piece, 7)}, kodr.ErrCodedDataLengthMismatch},
+		{&kodr_internals.CodedPiece{Vector: make(kodr_internals.CodingVector, 4), Piece: make(kodr_internals.Piece, 8), Version: 1}, kodr.ErrVersionMismatch},
+		{&kodr_internals.CodedPiece{Vector: make(kodr_internals.CodingVector, 4), Piece: make(kodr_internals.Piece, 8), Field: gf65536.DefaultField().ID()}, kodr.ErrFieldMismatch},
+	} {
+		if err := a.AddScaled(tc.other, 1, f); !errors.Is(err, tc.expected) {
+			t.Fatalf("expected: %s, found: %v\n", tc.expected, err)
+		}
+		if _, err := kodr_internals.LinearCombination([]*kodr_internals.CodedPiece{a, tc.other}, []uint32{1, 1}, f); !errors.Is(err, tc.expected) {
+			t.Fatalf("expected: %s, found: %v\n", tc.expected, err)
+		}
+	}
+
+	odd := &kodr_internals.CodedPiece{Vector: make(kodr_internals.CodingVector, 4), Piece: make(kodr_internals.Piece, 7)}
+	if err := odd.Scale(2, gf65536.DefaultField()); !errors.Is(err, kodr.ErrPieceSizeNotMultipleOfSymbolSize) {
+		t.Fatalf("expected: %s\n", kodr.ErrPieceSizeNotMultipleOfSymbolSize)
+	}
+	if _, err := kodr_internals.LinearCombination([]*kodr_internals.CodedPiece{a}, []uint32{1, 2}, f); !errors.Is(err, kodr.ErrCoefficientCountMismatch) {
+		t.Fatalf("expected: %s\n", kodr.ErrCoefficientCountMismatch)
+	}
+	if _, err := kodr_internals.LinearCombination(nil, nil, f); !errors.Is(err, kodr.ErrEmptyCombination) {
+		t.Fatalf("expected: %s\n", kodr.ErrEmptyCombination)
+	}
+
+	// coefficients wider than symbol of field aren't its elements
+	for _, tc := range []struct {
+		f     field.Field
+		coeff uint32
+	}{{f, 1 << 8}, {gf65536.DefaultField(), 1 << 16}} {
+		if err := a.Scale(tc.coeff, tc.f); !errors.Is(err, kodr.ErrCoefficientOutOfField) {
+			t.Fatalf("expected: %s\n", kodr.ErrCoefficientOutOfField)
+		}
+		if err := a.AddScaled(a, tc.coeff, tc.f); !errors.Is(err, kodr.ErrCoefficientOutOfField) {
+			t.Fatalf("expected: %s\n", kodr.ErrCoefficientOutOfField)
+		}
+		if _, err := kodr_internals.LinearCombination([]*kodr_internals.CodedPiece{a}, []uint32{tc.coeff}, tc.f); !errors.Is(err, kodr.ErrCoefficientOutOfField) {
+			t.Fatalf("expected: %s\n", kodr.ErrCoefficientOutOfField)
+		}
+	}
+	if err := a.Scale(1<<8-1, f); err != nil {
+		t.Fatal(err.Error())
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/itzmeanjan/kodr"
 	"github.com/itzmeanjan/kodr/kodr_internals/gf256"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf65536"
 )
 
 // A piece of data is nothing but a byte array
@@ -28,6 +29,14 @@ func (p *Piece) Multiply(piece Piece, by byte) {
 	}
 }
 
+// Same as `Multiply`, but symbols are 16-bit wide i.e. GF(2^16)
+// elements, each taking two consecutive bytes of piece
+//
+// `by` is coding coefficient
+func (p *Piece) MultiplyGf65536(piece Piece, by uint16) {
+	gf65536.MulAddSlice(*p, piece, gf65536.New(by))
+}
+
 // One component of coded piece; holding
 // information regarding how original pieces are
 // combined together
@@ -35,9 +44,22 @@ type CodingVector []byte
 
 // Coded piece along with randomly generated coding vector
 // to be used by recoder/ decoder
+//
+// `Field` identifies finite field, piece is coded over, so that
+// decoder can refuse pieces coded over some other field. Zero
+// denotes unknown field, which is the case for pieces reconstructed
+// from flattened data, as identifier isn't part of it. Field of such
+// pieces can't be checked, so it's up to caller to decode those over
+// same field, they were coded over
+//
+// `Version` identifies version of ( mutable ) object, piece is coded
+// from, so that pieces coded before & after some update aren't mixed,
+// while stale pieces can be brought up to date by applying `Delta`
 type CodedPiece struct {
-	Vector CodingVector
-	Piece  Piece
+	Vector  CodingVector
+	Piece   Piece
+	Field   uint32
+	Version uint64
 }
 
 // Total length of coded piece --- len(coding_vector) + len(piece)
@@ -94,6 +116,12 @@ func GenerateCodingVector(n uint) CodingVector {
 	return vector
 }
 
+// Generates random coding vector of N coefficients, each being
+// a GF(2^16) element, so it's 2*N bytes long
+func GenerateCodingVectorGf65536(n uint) CodingVector {
+	return GenerateCodingVector(n * gf65536.SymbolSize)
+}
+
 // Given whole chunk of data & desired size of each pieces ( in terms of bytes ),
 // it'll split chunk into pieces, which are to be used by encoder for performing RLNC
 //
@@ -131,6 +159,16 @@ func OriginalPiecesFromDataAndPieceSize(data []byte, pieceSize uint) ([]Piece, u
 	return pieces, padding, nil
 }
 
+// Same as `OriginalPiecesFromDataAndPieceSize`, but requested piece size must
+// be a multiple of symbol size ( in bytes ) of finite field, being used for coding
+func OriginalPiecesFromDataAndPieceSizeWithSymbolSize(data []byte, pieceSize uint, symbolSize uint) ([]Piece, uint, error) {
+	if pieceSize%symbolSize != 0 {
+		return nil, 0, kodr.ErrPieceSizeNotMultipleOfSymbolSize
+	}
+
+	return OriginalPiecesFromDataAndPieceSize(data, pieceSize)
+}
+
 // When you want to split whole data chunk into N-many original pieces, this function
 // will do it, while appending extra zero bytes ( read padding bytes ) at end of last piece
 // if exact division is not feasible
@@ -165,6 +203,35 @@ func OriginalPiecesFromDataAndPieceCount(data []byte, pieceCount uint) ([]Piece,
 	return splitted, padding, err
 }
 
+// Same as `OriginalPiecesFromDataAndPieceCount`, but size of each piece is
+// rounded up to be a multiple of symbol size ( in bytes ) of finite field,
+// being used for coding, appending more padding bytes, if required
+func OriginalPiecesFromDataAndPieceCountWithSymbolSize(data []byte, pieceCount uint, symbolSize uint) ([]Piece, uint, error) {
+	if pieceCount < 2 {
+		return nil, 0, kodr.ErrBadPieceCount
+	}
+
+	if int(pieceCount) > len(data) {
+		return nil, 0, kodr.ErrPieceCountMoreThanTotalBytes
+	}
+
+	pieceSize := (uint(len(data)) + (pieceCount - 1)) / pieceCount
+	pieceSize = (pieceSize + (symbolSize - 1)) / symbolSize * symbolSize
+	padding := pieceCount*pieceSize - uint(len(data))
+
+	data_ := make([]byte, pieceSize*pieceCount)
+	if n := copy(data_, data); n != len(data) {
+		return nil, 0, kodr.ErrCopyFailedDuringPieceConstruction
+	}
+
+	pieces := make([]Piece, pieceCount)
+	for i := range pieceCount {
+		pieces[i] = data_[pieceSize*i : pieceSize*(i+1)]
+	}
+
+	return pieces, padding, nil
+}
+
 // Before recoding can be performed, coded pieces byte array i.e. []<< coding vector ++ coded piece >>
 // where each coded piece is << coding vector ++ coded piece >> ( flattened ) is splitted into
 // structured data i.e. into components {coding vector, coded piece}, where how many coded pieces are
@@ -154,3 +154,34 @@ func TestIsSystematic(t *testing.T) {
 		t.Fatalf("%v shouldn't be systematic\n", piece_4)
 	}
 }
+
+func TestSplitDataWithSymbolSize(t *testing.T) {
+	size := uint(2<<10 + rand.Intn(2<<10))
+	data := generateData(size)
+
+	for range 1 << 5 {
+		count := uint(2<<1 + rand.Intn(2<<8))
+		pieces, padding, err := kodr_internals.OriginalPiecesFromDataAndPieceCountWithSymbolSize(data, count, 2)
+		if err != nil {
+			t.Fatalf("didn't expect error: %s\n", err)
+		}
+
+		if len(pieces) != int(count) || len(pieces[0])%2 != 0 {
+			t.Fatalf("expected %d pieces, each of even length\n", count)
+		}
+		if uint(len(pieces[0]))*count != size+padding {
+			t.Fatal("padding doesn't add up")
+		}
+		joined := make([]byte, 0, size+padding)
+		for i := range pieces {
+			joined = append(joined, pieces[i]...)
+		}
+		if !bytes.Equal(data, joined[:size]) {
+			t.Fatal("pieces don't hold original data")
+		}
+	}
+
+	if _, _, err := kodr_internals.OriginalPiecesFromDataAndPieceSizeWithSymbolSize(data, 3, 2); !(err != nil && errors.Is(err, kodr.ErrPieceSizeNotMultipleOfSymbolSize)) {
+		t.Fatalf("expected: %s\n", kodr.ErrPieceSizeNotMultipleOfSymbolSize)
+	}
+}
@@ -0,0 +1,57 @@
+package kodr_internals
+
+import (
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+)
+
+// Delta - Change of one original piece, as produced by encoder's `Update`,
+// which holders of coded pieces apply locally, so that those stay consistent
+// with updated object, without being re-downloaded or re-encoded
+//
+// As each coded piece is a linear combination of original pieces, changing
+// piece at `Index` from old to new changes coded piece by coefficient[Index]
+// × ( new - old ), where `Diff` = new - old
+type Delta struct {
+	Index   uint
+	Diff    Piece
+	Field   uint32
+	Version uint64
+}
+
+// Apply - Brings coded piece, of version just before delta's, up to date,
+// in-place, i.e. piece += coefficient[Index] × Diff, where coefficients
+// are elements of given finite field
+//
+// If coded piece is of some other version, returns error, so that
+// same delta isn't applied twice & no delta is skipped
+func (d *Delta) Apply(piece *CodedPiece, f field.Field) error {
+	if d.Field != f.ID() || (piece.Field != 0 && piece.Field != f.ID()) {
+		return kodr.ErrFieldMismatch
+	}
+	if piece.Version+1 != d.Version {
+		return kodr.ErrVersionMismatch
+	}
+	if d.Index >= uint(len(piece.Vector))/f.SymbolSize() {
+		return kodr.ErrPieceOutOfBound
+	}
+	if len(d.Diff) != len(piece.Piece) {
+		return kodr.ErrCodedDataLengthMismatch
+	}
+
+	if c := f.Symbol(piece.Vector, d.Index); c != 0 {
+		f.MulAddSlice(piece.Piece, d.Diff, c)
+	}
+	piece.Version = d.Version
+	return nil
+}
+
+// Computes delta, changing original piece from old to new, over given
+// finite field, bringing coded pieces up to given version
+func NewDelta(idx uint, old, new Piece, version uint64, f field.Field) *Delta {
+	diff := make(Piece, len(new))
+	copy(diff, new)
+	f.MulAddSlice(diff, old, f.Sub(0, 1))
+
+	return &Delta{Index: idx, Diff: diff, Field: f.ID(), Version: version}
+}
@@ -0,0 +1,47 @@
+// Finite field abstraction, so that encoders, recoders & decoders can
+// work over any field, without knowing how its arithmetic is implemented
+//
+// Field elements are passed around as uint32, while in coding vectors &
+// pieces they're kept serialized, each element taking `SymbolSize()`
+// bytes. So a coding vector of N coefficients is N * SymbolSize() bytes
+// long & a piece of M bytes holds M / SymbolSize() symbols
+package field
+
+import (
+	"github.com/itzmeanjan/kodr/kodr_internals/gf256"
+)
+
+type Field interface {
+	// Identifies field along with its construction parameters i.e.
+	// irreducible polynomial & generator, never zero
+	ID() uint32
+
+	// #-of bytes, each serialized element takes
+	SymbolSize() uint
+
+	// Basic arithmetic, inverse of zero element returns error
+	Add(a, b uint32) uint32
+	Sub(a, b uint32) uint32
+	Mul(a, b uint32) uint32
+	Inv(a uint32) (uint32, error)
+
+	// Uniformly random element
+	Random() uint32
+
+	// Bulk kernels, working on byte slices holding serialized elements,
+	// computing dst += c * src & dst = c * dst, symbol by symbol
+	MulAddSlice(dst, src []byte, c uint32)
+	MulSlice(dst []byte, c uint32)
+
+	// Reads/ writes i-th serialized element of a byte slice
+	Symbol(buf []byte, i uint) uint32
+	SetSymbol(buf []byte, i uint, v uint32)
+
+	// Serialized coding vector of N random coefficients
+	RandomVector(n uint) []byte
+}
+
+// GF(2^8), which is used, when no field is explicitly chosen
+func Default() Field {
+	return gf256.DefaultField()
+}
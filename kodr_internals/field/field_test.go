@@ -0,0 +1,87 @@
+package field_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf256"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf65536"
+)
+
+func fields() map[string]field.Field {
+	aes, _ := gf256.NewField(gf256.AESPolynomial, gf256.AESGenerator)
+	return map[string]field.Field{
+		"GF(2^8)":     field.Default(),
+		"GF(2^8)/AES": aes,
+		"GF(2^16)":    gf65536.DefaultField(),
+	}
+}
+
+// Field axioms must hold, irrespective of how arithmetic is implemented
+func TestFieldArithmetic(t *testing.T) {
+	for name, f := range fields() {
+		t.Run(name, func(t *testing.T) {
+			if _, err := f.Inv(0); err == nil {
+				t.Fatal("expected inverting additive identity to fail")
+			}
+
+			for range 10_000 {
+				a, b, c := f.Random(), f.Random(), f.Random()
+
+				if f.Sub(f.Add(a, b), b) != a {
+					t.Fatalf("(%d + %d) - %d != %d\n", a, b, b, a)
+				}
+				if f.Mul(a, f.Add(b, c)) != f.Add(f.Mul(a, b), f.Mul(a, c)) {
+					t.Fatalf("multiplication doesn't distribute over addition for %d, %d, %d\n", a, b, c)
+				}
+				if a == 0 {
+					continue
+				}
+
+				inv, err := f.Inv(a)
+				if err != nil {
+					t.Fatal(err.Error())
+				}
+				if f.Mul(a, inv) != 1 {
+					t.Fatalf("%d * %d != 1\n", a, inv)
+				}
+			}
+		})
+	}
+}
+
+// Bulk kernels must agree with element-wise arithmetic
+func TestFieldBulkKernels(t *testing.T) {
+	const symbols = 1 << 10
+
+	for name, f := range fields() {
+		t.Run(name, func(t *testing.T) {
+			src := f.RandomVector(symbols)
+			dst := f.RandomVector(symbols)
+			if uint(len(src)) != symbols*f.SymbolSize() {
+				t.Fatalf("expected vector of %dB, found %dB\n", symbols*f.SymbolSize(), len(src))
+			}
+
+			c := f.Random()
+			expected := make([]byte, len(dst))
+			for i := range uint(symbols) {
+				f.SetSymbol(expected, i, f.Add(f.Symbol(dst, i), f.Mul(c, f.Symbol(src, i))))
+			}
+
+			f.MulAddSlice(dst, src, c)
+			if !bytes.Equal(dst, expected) {
+				t.Fatal("dst += c * src doesn't match element-wise computation")
+			}
+
+			for i := range uint(symbols) {
+				f.SetSymbol(expected, i, f.Mul(c, f.Symbol(dst, i)))
+			}
+
+			f.MulSlice(dst, c)
+			if !bytes.Equal(dst, expected) {
+				t.Fatal("dst = c * dst doesn't match element-wise computation")
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package gf2
+
+import (
+	"math/bits"
+
+	"github.com/itzmeanjan/kodr"
+)
+
+// Decoder state over GF(2), where coefficient matrix is kept
+// in reduced row echelon form, after every piece is added
+//
+// Each row of coefficient matrix is a bit vector, stored in 64-bit
+// words, so that eliminating one row using another is just
+// XOR-ing ceil(N/64) words ( plus XOR-ing respective coded pieces )
+type DecoderState struct {
+	pieceCount uint
+	coeffs     [][]uint64
+	coded      [][]byte
+	pivots     []uint
+	pivotRow   []int
+}
+
+func (d *DecoderState) xorRows(dst, src int) {
+	for k := range d.coeffs[dst] {
+		d.coeffs[dst][k] ^= d.coeffs[src][k]
+	}
+	Xor(d.coded[dst], d.coded[src])
+}
+
+// Adds a new coded piece to decoder state, eliminating it using
+// already present rows. If it turns out to be linearly independent
+// of those, it's kept & used for eliminating its pivot column from
+// all other rows, which keeps the matrix in reduced row echelon form
+//
+// Returns true if piece was useful i.e. it increased rank, while if coding
+// vector isn't ceil(N/8) bytes long or has bits set beyond N or piece
+// length differs from that of pieces added before, returns error
+//
+// Note: Coded piece is copied, caller's slices are never modified
+func (d *DecoderState) AddPiece(vector []byte, piece []byte) (bool, error) {
+	if uint(len(vector)) != VectorLen(d.pieceCount) {
+		return false, kodr.ErrCodingVectorLengthMismatch
+	}
+	if tail := d.pieceCount % 8; tail != 0 && vector[len(vector)-1]>>tail != 0 {
+		return false, kodr.ErrCodingVectorPaddingNotZero
+	}
+	if len(d.coded) > 0 && len(piece) != len(d.coded[0]) {
+		return false, kodr.ErrCodedDataLengthMismatch
+	}
+
+	coeffs := Words(vector, d.pieceCount)
+	coded := make([]byte, len(piece))
+	copy(coded, piece)
+
+	d.coeffs = append(d.coeffs, coeffs)
+	d.coded = append(d.coded, coded)
+	row := len(d.coeffs) - 1
+
+	for r, p := range d.pivots {
+		if coeffs[p>>6]&(1<<(p&63)) != 0 {
+			d.xorRows(row, r)
+		}
+	}
+
+	pivot := -1
+	for k, w := range coeffs {
+		if w != 0 {
+			pivot = 64*k + bits.TrailingZeros64(w)
+			break
+		}
+	}
+
+	if pivot < 0 {
+		d.coeffs = d.coeffs[:row]
+		d.coded = d.coded[:row]
+		return false, nil
+	}
+
+	p := uint(pivot)
+	for r := range d.pivots {
+		if d.coeffs[r][p>>6]&(1<<(p&63)) != 0 {
+			d.xorRows(r, row)
+		}
+	}
+
+	d.pivots = append(d.pivots, p)
+	d.pivotRow[p] = row
+	return true, nil
+}
+
+// #-of pieces coded together i.e. rank required for decoding
+func (d *DecoderState) Expected() uint {
+	return d.pieceCount
+}
+
+// #-of linearly independent pieces received so far
+func (d *DecoderState) Rank() uint {
+	return uint(len(d.coeffs))
+}
+
+// Row at index `i` ( < Rank() ) of reduced basis, as bit-packed
+// coding vector & coded piece
+//
+// Note: Coded piece isn't copied, so it must not be modified
+func (d *DecoderState) Row(i uint) ([]byte, []byte) {
+	return Bytes(d.coeffs[i], d.pieceCount), d.coded[i]
+}
+
+// Pivot column of row at index `i` ( < Rank() ) of reduced basis
+func (d *DecoderState) Pivot(i uint) uint {
+	return d.pivots[i]
+}
+
+// Length of coded pieces in bytes, if at least one
+// useful piece is received, otherwise 0
+func (d *DecoderState) PieceLength() uint {
+	if len(d.coded) == 0 {
+		return 0
+	}
+	return uint(len(d.coded[0]))
+}
+
+// Request decoded piece by index, which is available as soon as
+// row having pivot at that column has only a single bit set
+//
+// Note: Returned piece is copied into newly allocated memory, unless
+// whole decoding has already happened
+func (d *DecoderState) GetPiece(idx uint) ([]byte, error) {
+	if idx >= d.pieceCount {
+		return nil, kodr.ErrPieceOutOfBound
+	}
+
+	row := d.pivotRow[idx]
+	if row < 0 {
+		return nil, kodr.ErrPieceNotDecodedYet
+	}
+
+	if d.Rank() >= d.pieceCount {
+		return d.coded[row], nil
+	}
+
+	weight := 0
+	for _, w := range d.coeffs[row] {
+		weight += bits.OnesCount64(w)
+	}
+	if weight != 1 {
+		return nil, kodr.ErrPieceNotDecodedYet
+	}
+
+	buf := make([]byte, len(d.coded[row]))
+	copy(buf, d.coded[row])
+	return buf, nil
+}
+
+func NewDecoderState(pieceCount uint) *DecoderState {
+	pivotRow := make([]int, pieceCount)
+	for i := range pivotRow {
+		pivotRow[i] = -1
+	}
+
+	return &DecoderState{
+		pieceCount: pieceCount,
+		coeffs:     make([][]uint64, 0, pieceCount),
+		coded:      make([][]byte, 0, pieceCount),
+		pivots:     make([]uint, 0, pieceCount),
+		pivotRow:   pivotRow,
+	}
+}
@@ -0,0 +1,100 @@
+// Arithmetic over GF(2), where coding coefficients are bits. Coding
+// vectors are bit-packed i.e. coefficient of i-th piece lives at bit
+// ( i % 8 ) of byte ( i / 8 ), so N coefficients take ceil(N/8) bytes
+//
+// Addition is XOR, multiplication is AND, so coding pieces together
+// is nothing but XOR-ing selected pieces
+package gf2
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/binary"
+)
+
+// Bytes required for holding N bit-packed coefficients
+func VectorLen(n uint) uint {
+	return (n + 7) / 8
+}
+
+// Coefficient of i-th piece
+func Get(vector []byte, i uint) bool {
+	return vector[i>>3]&(1<<(i&7)) != 0
+}
+
+// Sets coefficient of i-th piece to 1
+func Set(vector []byte, i uint) {
+	vector[i>>3] |= 1 << (i & 7)
+}
+
+// Flips coefficient of i-th piece
+func Flip(vector []byte, i uint) {
+	vector[i>>3] ^= 1 << (i & 7)
+}
+
+// Element-wise addition of two equal length byte slices,
+// written back to `dst`
+func Xor(dst, src []byte) {
+	subtle.XORBytes(dst, dst, src)
+}
+
+// Returns true if all coefficients are zero
+func IsZero(vector []byte) bool {
+	for _, v := range vector {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Generates random bit-packed coding vector of N coefficients,
+// which is never all zero, as such vector is useless
+//
+// Bits beyond N-th coefficient, in last byte, are always unset
+func Random(n uint) []byte {
+	vector := make([]byte, VectorLen(n))
+	for {
+		// ignoring error, because it always succeeds
+		rand.Read(vector)
+		if rem := n & 7; rem != 0 {
+			vector[len(vector)-1] &= byte(1<<rem) - 1
+		}
+
+		if !IsZero(vector) {
+			return vector
+		}
+	}
+}
+
+// Bit-packed coding vector, having only i-th coefficient set
+func Unit(n, i uint) []byte {
+	vector := make([]byte, VectorLen(n))
+	Set(vector, i)
+	return vector
+}
+
+// Loads bit-packed coding vector into 64-bit words, so that
+// elimination can work on a word at a time
+func Words(vector []byte, n uint) []uint64 {
+	words := make([]uint64, (n+63)/64)
+	var buf [8]byte
+	for i := range words {
+		clear(buf[:])
+		copy(buf[:], vector[min(8*i, len(vector)):])
+		words[i] = binary.LittleEndian.Uint64(buf[:])
+	}
+	return words
+}
+
+// Stores 64-bit words back into bit-packed coding vector of
+// N coefficients
+func Bytes(words []uint64, n uint) []byte {
+	vector := make([]byte, VectorLen(n))
+	var buf [8]byte
+	for i := range words {
+		binary.LittleEndian.PutUint64(buf[:], words[i])
+		copy(vector[min(8*i, len(vector)):], buf[:])
+	}
+	return vector
+}
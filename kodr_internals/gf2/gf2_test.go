@@ -0,0 +1,94 @@
+package gf2_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf2"
+)
+
+func TestBitPacking(t *testing.T) {
+	for _, n := range []uint{1, 7, 8, 9, 63, 64, 65, 130} {
+		vector := gf2.Random(n)
+		if uint(len(vector)) != gf2.VectorLen(n) {
+			t.Fatalf("expected %d bytes, found %d\n", gf2.VectorLen(n), len(vector))
+		}
+
+		if rem := n & 7; rem != 0 && vector[len(vector)-1]>>rem != 0 {
+			t.Fatalf("expected bits beyond %d-th coefficient to be unset\n", n)
+		}
+
+		if !bytes.Equal(gf2.Bytes(gf2.Words(vector, n), n), vector) {
+			t.Fatal("bit vector doesn't survive round trip through 64-bit words")
+		}
+
+		unit := gf2.Unit(n, n-1)
+		if !gf2.Get(unit, n-1) {
+			t.Fatalf("expected coefficient %d to be set\n", n-1)
+		}
+		gf2.Flip(unit, n-1)
+		if !gf2.IsZero(unit) {
+			t.Fatal("expected all coefficients to be unset")
+		}
+	}
+}
+
+func TestDecoderState(t *testing.T) {
+	// pieces coded as 0b011, 0b110, 0b101 & 0b001, where
+	// 3rd one is sum of first two
+	state := gf2.NewDecoderState(3)
+	pieces := [][]byte{{1}, {2}, {4}}
+	coded := [][]byte{{1 ^ 2}, {2 ^ 4}, {1 ^ 4}, {1}}
+	vectors := [][]byte{{0b011}, {0b110}, {0b101}, {0b001}}
+	useful := []bool{true, true, false, true}
+
+	for i := range coded {
+		if ok, err := state.AddPiece(vectors[i], coded[i]); err != nil {
+			t.Fatal(err.Error())
+		} else if ok != useful[i] {
+			t.Fatalf("expected piece %d to be useful = %v\n", i, useful[i])
+		}
+	}
+
+	if state.Rank() != 3 {
+		t.Fatalf("expected rank 3, found %d\n", state.Rank())
+	}
+
+	for i := range pieces {
+		piece, err := state.GetPiece(uint(i))
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if !bytes.Equal(piece, pieces[i]) {
+			t.Fatal("decoded data doesn't match !")
+		}
+	}
+
+	if _, err := state.GetPiece(3); !(err != nil && errors.Is(err, kodr.ErrPieceOutOfBound)) {
+		t.Fatalf("expected: %s\n", kodr.ErrPieceOutOfBound)
+	}
+}
+
+func TestDecoderStateMalformedPiece(t *testing.T) {
+	state := gf2.NewDecoderState(3)
+
+	if _, err := state.AddPiece([]byte{0b001, 0}, []byte{1}); !errors.Is(err, kodr.ErrCodingVectorLengthMismatch) {
+		t.Fatalf("expected: %s\n", kodr.ErrCodingVectorLengthMismatch)
+	}
+	// 4th coefficient doesn't exist, as only 3 pieces are coded together
+	if _, err := state.AddPiece([]byte{0b1000}, []byte{1}); !errors.Is(err, kodr.ErrCodingVectorPaddingNotZero) {
+		t.Fatalf("expected: %s\n", kodr.ErrCodingVectorPaddingNotZero)
+	}
+	if _, err := state.AddPiece([]byte{0b001}, []byte{1}); err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := state.AddPiece([]byte{0b010}, []byte{1, 2}); !errors.Is(err, kodr.ErrCodedDataLengthMismatch) {
+		t.Fatalf("expected: %s\n", kodr.ErrCodedDataLengthMismatch)
+	}
+
+	if state.Rank() != 1 {
+		t.Fatalf("expected rank 1, found %d\n", state.Rank())
+	}
+}
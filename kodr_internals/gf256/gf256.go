@@ -1,8 +1,13 @@
 /// This package is auto-generated using Grok, given contents of https://github.com/itzmeanjan/rlnc/blob/02bbf56f7e5291997e1d651288af52e6268969a2/src/common/gf256.rs as input.
+///
+/// Logarithm & exponentiation tables are no more hardcoded, rather generated
+/// during package initialization, for any chosen irreducible polynomial & generator.
 
 package gf256
 
 import (
+	crypto_rand "crypto/rand"
+	"crypto/subtle"
 	"math/rand"
 
 	"github.com/itzmeanjan/kodr"
@@ -11,36 +16,40 @@ import (
 // gf256_ORDER represents the order of the GF(2^8) field
 const gf256_ORDER = 256
 
-// gf256_LOG_TABLE is the logarithm table for GF(2^8)
-var gf256_LOG_TABLE = [gf256_ORDER]uint8{
-	0, 0, 1, 25, 2, 50, 26, 198, 3, 223, 51, 238, 27, 104, 199, 75, 4, 100, 224, 14, 52, 141, 239, 129, 28, 193, 105, 248, 200, 8, 76, 113, 5, 138, 101, 47,
-	225, 36, 15, 33, 53, 147, 142, 218, 240, 18, 130, 69, 29, 181, 194, 125, 106, 39, 249, 185, 201, 154, 9, 120, 77, 228, 114, 166, 6, 191, 139, 98, 102, 221,
-	48, 253, 226, 152, 37, 179, 16, 145, 34, 136, 54, 208, 148, 206, 143, 150, 219, 189, 241, 210, 19, 92, 131, 56, 70, 64, 30, 66, 182, 163, 195, 72, 126,
-	110, 107, 58, 40, 84, 250, 133, 186, 61, 202, 94, 155, 159, 10, 21, 121, 43, 78, 212, 229, 172, 115, 243, 167, 87, 7, 112, 192, 247, 140, 128, 99, 13, 103,
-	74, 222, 237, 49, 197, 254, 24, 227, 165, 153, 119, 38, 184, 180, 124, 17, 68, 146, 217, 35, 32, 137, 46, 55, 63, 209, 91, 149, 188, 207, 205, 144, 135,
-	151, 178, 220, 252, 190, 97, 242, 86, 211, 171, 20, 42, 93, 158, 132, 60, 57, 83, 71, 109, 65, 162, 31, 45, 67, 216, 183, 123, 164, 118, 196, 23, 73, 236,
-	127, 12, 111, 246, 108, 161, 59, 82, 41, 157, 85, 170, 251, 96, 134, 177, 187, 204, 62, 90, 203, 89, 95, 176, 156, 169, 160, 81, 11, 245, 22, 235, 122,
-	117, 44, 215, 79, 174, 213, 233, 230, 231, 173, 232, 116, 214, 244, 234, 168, 80, 88, 175,
-}
-
-// gf256_EXP_TABLE is the exponentiation table for GF(2^8)
-var gf256_EXP_TABLE = [2*gf256_ORDER - 2]uint8{
-	1, 2, 4, 8, 16, 32, 64, 128, 29, 58, 116, 232, 205, 135, 19, 38, 76, 152, 45, 90, 180, 117, 234, 201, 143, 3, 6, 12, 24, 48, 96, 192, 157, 39, 78, 156, 37,
-	74, 148, 53, 106, 212, 181, 119, 238, 193, 159, 35, 70, 140, 5, 10, 20, 40, 80, 160, 93, 186, 105, 210, 185, 111, 222, 161, 95, 190, 97, 194, 153, 47, 94,
-	188, 101, 202, 137, 15, 30, 60, 120, 240, 253, 231, 211, 187, 107, 214, 177, 127, 254, 225, 223, 163, 91, 182, 113, 226, 217, 175, 67, 134, 17, 34, 68,
-	136, 13, 26, 52, 104, 208, 189, 103, 206, 129, 31, 62, 124, 248, 237, 199, 147, 59, 118, 236, 197, 151, 51, 102, 204, 133, 23, 46, 92, 184, 109, 218, 169,
-	79, 158, 33, 66, 132, 21, 42, 84, 168, 77, 154, 41, 82, 164, 85, 170, 73, 146, 57, 114, 228, 213, 183, 115, 230, 209, 191, 99, 198, 145, 63, 126, 252, 229,
-	215, 179, 123, 246, 241, 255, 227, 219, 171, 75, 150, 49, 98, 196, 149, 55, 110, 220, 165, 87, 174, 65, 130, 25, 50, 100, 200, 141, 7, 14, 28, 56, 112,
-	224, 221, 167, 83, 166, 81, 162, 89, 178, 121, 242, 249, 239, 195, 155, 43, 86, 172, 69, 138, 9, 18, 36, 72, 144, 61, 122, 244, 245, 247, 243, 251, 235,
-	203, 139, 11, 22, 44, 88, 176, 125, 250, 233, 207, 131, 27, 54, 108, 216, 173, 71, 142, 1, 2, 4, 8, 16, 32, 64, 128, 29, 58, 116, 232, 205, 135, 19, 38,
-	76, 152, 45, 90, 180, 117, 234, 201, 143, 3, 6, 12, 24, 48, 96, 192, 157, 39, 78, 156, 37, 74, 148, 53, 106, 212, 181, 119, 238, 193, 159, 35, 70, 140, 5,
-	10, 20, 40, 80, 160, 93, 186, 105, 210, 185, 111, 222, 161, 95, 190, 97, 194, 153, 47, 94, 188, 101, 202, 137, 15, 30, 60, 120, 240, 253, 231, 211, 187,
-	107, 214, 177, 127, 254, 225, 223, 163, 91, 182, 113, 226, 217, 175, 67, 134, 17, 34, 68, 136, 13, 26, 52, 104, 208, 189, 103, 206, 129, 31, 62, 124, 248,
-	237, 199, 147, 59, 118, 236, 197, 151, 51, 102, 204, 133, 23, 46, 92, 184, 109, 218, 169, 79, 158, 33, 66, 132, 21, 42, 84, 168, 77, 154, 41, 82, 164, 85,
-	170, 73, 146, 57, 114, 228, 213, 183, 115, 230, 209, 191, 99, 198, 145, 63, 126, 252, 229, 215, 179, 123, 246, 241, 255, 227, 219, 171, 75, 150, 49, 98,
-	196, 149, 55, 110, 220, 165, 87, 174, 65, 130, 25, 50, 100, 200, 141, 7, 14, 28, 56, 112, 224, 221, 167, 83, 166, 81, 162, 89, 178, 121, 242, 249, 239,
-	195, 155, 43, 86, 172, 69, 138, 9, 18, 36, 72, 144, 61, 122, 244, 245, 247, 243, 251, 235, 203, 139, 11, 22, 44, 88, 176, 125, 250, 233, 207, 131, 27, 54,
-	108, 216, 173, 71, 142,
+// Irreducible polynomial x^8 + x^4 + x^3 + x^2 + 1 ( = 0x11d ) & its
+// primitive element x ( = 2 ), used by default, same as Rust rlnc crate
+const (
+	DefaultPolynomial uint16 = 0x11d
+	DefaultGenerator  uint8  = 2
+)
+
+// Irreducible polynomial x^8 + x^4 + x^3 + x + 1 ( = 0x11b ), used by AES,
+// for which x isn't primitive, but x + 1 ( = 3 ) is
+const (
+	AESPolynomial uint16 = 0x11b
+	AESGenerator  uint8  = 3
+)
+
+// gf256_LOG_TABLE is the logarithm table for GF(2^8), built with default
+// polynomial & generator
+var gf256_LOG_TABLE [gf256_ORDER]uint8
+
+// gf256_EXP_TABLE is the exponentiation table for GF(2^8), which is twice
+// as long, so that sum of two logarithms can be looked up without reducing
+// it modulo ( order - 1 )
+var gf256_EXP_TABLE [2*gf256_ORDER - 2]uint8
+
+var defaultField *Field
+
+func init() {
+	f, err := NewField(DefaultPolynomial, DefaultGenerator)
+	if err != nil {
+		panic(err)
+	}
+
+	defaultField = f
+	gf256_LOG_TABLE = f.log
+	gf256_EXP_TABLE = f.exp
 }
 
 // Gf256 represents an element in GF(2^8)
@@ -135,3 +144,172 @@ func (g Gf256) Equal(other Gf256) bool {
 func Random() Gf256 {
 	return Gf256{val: uint8(rand.Intn(256))}
 }
+
+// Field implements arithmetic over GF(2^8), constructed with some
+// irreducible polynomial of degree 8 & a primitive element of field,
+// in a form, encoders & decoders, which are generic over finite field, can use
+type Field struct {
+	polynomial uint16
+	generator  uint8
+	log        [gf256_ORDER]uint8
+	exp        [2*gf256_ORDER - 2]uint8
+}
+
+// NewField generates logarithm & exponentiation tables of GF(2^8), for given
+// irreducible polynomial ( with x^8 term i.e. in [0x100, 0x200) ) & generator
+//
+// Returns error if polynomial isn't of degree 8 or powers of generator don't
+// run through all non-zero elements, which is also the case when polynomial
+// is reducible
+func NewField(polynomial uint16, generator uint8) (*Field, error) {
+	if polynomial>>8 != 1 {
+		return nil, kodr.ErrInvalidGf256Polynomial
+	}
+
+	f := &Field{polynomial: polynomial, generator: generator}
+	seen := [gf256_ORDER]bool{}
+	x := uint8(1)
+	for i := range gf256_ORDER - 1 {
+		if seen[x] {
+			return nil, kodr.ErrNonPrimitiveGf256Generator
+		}
+		seen[x] = true
+
+		f.exp[i] = x
+		f.exp[i+gf256_ORDER-1] = x
+		f.log[x] = uint8(i)
+
+		x = mulPoly(x, generator, polynomial)
+	}
+
+	return f, nil
+}
+
+// Multiplies two polynomials over GF(2) & reduces product modulo
+// given polynomial of degree 8, without using any table
+func mulPoly(a, b uint8, polynomial uint16) uint8 {
+	res := uint16(0)
+	a_ := uint16(a)
+	for ; b != 0; b >>= 1 {
+		if b&1 == 1 {
+			res ^= a_
+		}
+
+		a_ <<= 1
+		if a_&gf256_ORDER != 0 {
+			a_ ^= polynomial
+		}
+	}
+	return uint8(res)
+}
+
+// DefaultField returns GF(2^8), with polynomial 0x11d & generator 2,
+// where each element takes 1 byte
+func DefaultField() *Field {
+	return defaultField
+}
+
+// Polynomial returns irreducible polynomial, field is constructed with
+func (f *Field) Polynomial() uint16 {
+	return f.polynomial
+}
+
+// Generator returns primitive element, used for generating tables
+func (f *Field) Generator() uint8 {
+	return f.generator
+}
+
+// ID identifies field along with its construction parameters, so that
+// pieces coded over different fields can be told apart
+func (f *Field) ID() uint32 {
+	return 8<<24 | uint32(f.polynomial&0xff)<<8 | uint32(f.generator)
+}
+
+// SymbolSize returns #-of bytes taken by each element
+func (f *Field) SymbolSize() uint {
+	return 1
+}
+
+// Add performs addition (XOR) of two elements
+func (f *Field) Add(a, b uint32) uint32 {
+	return a ^ b
+}
+
+// Sub performs subtraction (XOR) of two elements
+func (f *Field) Sub(a, b uint32) uint32 {
+	return a ^ b
+}
+
+func (f *Field) mul(a, b uint8) uint8 {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return f.exp[int(f.log[a])+int(f.log[b])]
+}
+
+// Mul performs multiplication of two elements
+func (f *Field) Mul(a, b uint32) uint32 {
+	return uint32(f.mul(uint8(a), uint8(b)))
+}
+
+// Inv computes multiplicative inverse, returns error for zero element
+func (f *Field) Inv(a uint32) (uint32, error) {
+	if uint8(a) == 0 {
+		return 0, kodr.ErrCannotInvertGf256AdditiveIndentity
+	}
+	return uint32(f.exp[(gf256_ORDER-1)-int(f.log[uint8(a)])]), nil
+}
+
+// Random generates a random element
+func (f *Field) Random() uint32 {
+	return uint32(Random().Get())
+}
+
+// MulAddSlice computes dst += c * src, byte by byte
+func (f *Field) MulAddSlice(dst, src []byte, c uint32) {
+	switch uint8(c) {
+	case 0:
+		return
+	case 1:
+		// addition is XOR, which can be done word by word
+		subtle.XORBytes(dst[:len(src)], dst[:len(src)], src)
+		return
+	}
+
+	logC := int(f.log[uint8(c)])
+	for i := range src {
+		if src[i] == 0 {
+			continue
+		}
+		dst[i] ^= f.exp[int(f.log[src[i]])+logC]
+	}
+}
+
+// MulSlice computes dst = c * dst, byte by byte
+func (f *Field) MulSlice(dst []byte, c uint32) {
+	if uint8(c) == 1 {
+		return
+	}
+	for i := range dst {
+		dst[i] = f.mul(dst[i], uint8(c))
+	}
+}
+
+// Symbol reads i-th element of byte slice
+func (f *Field) Symbol(buf []byte, i uint) uint32 {
+	return uint32(buf[i])
+}
+
+// SetSymbol writes i-th element of byte slice
+func (f *Field) SetSymbol(buf []byte, i uint, v uint32) {
+	buf[i] = uint8(v)
+}
+
+// RandomVector generates N random coefficients, drawn
+// from cryptographically secure randomness source
+func (f *Field) RandomVector(n uint) []byte {
+	vector := make([]byte, n)
+	// ignoring error, because it always succeeds
+	crypto_rand.Read(vector)
+	return vector
+}
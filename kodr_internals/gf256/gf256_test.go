@@ -1,6 +1,7 @@
 package gf256_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/itzmeanjan/kodr"
@@ -38,3 +39,60 @@ func TestGf256Operations(t *testing.T) {
 		}
 	}
 }
+
+// Tables generated at init, must match ones of Rust rlnc crate,
+// which were earlier hardcoded, built with polynomial 0x11d
+func TestGf256DefaultTables(t *testing.T) {
+	x := gf256.One()
+	for range 8 {
+		x = x.Mul(gf256.PrimitiveElement())
+	}
+	if x.Get() != 29 {
+		t.Fatalf("expected x^8 = 29, found %d\n", x.Get())
+	}
+
+	f := gf256.DefaultField()
+	if f.Polynomial() != gf256.DefaultPolynomial || f.Generator() != gf256.DefaultGenerator {
+		t.Fatal("unexpected default field parameters")
+	}
+	if f.Mul(3, 7) != uint32(gf256.New(3).Mul(gf256.New(7)).Get()) {
+		t.Fatal("default field must agree with Gf256 arithmetic")
+	}
+}
+
+func TestGf256NewField(t *testing.T) {
+	if _, err := gf256.NewField(0x1d, 2); !errors.Is(err, kodr.ErrInvalidGf256Polynomial) {
+		t.Fatalf("expected: %s\n", kodr.ErrInvalidGf256Polynomial)
+	}
+	// x isn't primitive modulo AES polynomial
+	if _, err := gf256.NewField(gf256.AESPolynomial, 2); !errors.Is(err, kodr.ErrNonPrimitiveGf256Generator) {
+		t.Fatalf("expected: %s\n", kodr.ErrNonPrimitiveGf256Generator)
+	}
+	// x^8 + 1 = (x + 1)^8, reducible
+	if _, err := gf256.NewField(0x101, 3); !errors.Is(err, kodr.ErrNonPrimitiveGf256Generator) {
+		t.Fatalf("expected: %s\n", kodr.ErrNonPrimitiveGf256Generator)
+	}
+
+	aes, err := gf256.NewField(gf256.AESPolynomial, gf256.AESGenerator)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// well known AES multiplication, { 57 } x { 83 } = { c1 }
+	if aes.Mul(0x57, 0x83) != 0xc1 {
+		t.Fatalf("expected 0x57 * 0x83 = 0xc1, found %#x\n", aes.Mul(0x57, 0x83))
+	}
+	if aes.ID() == gf256.DefaultField().ID() {
+		t.Fatal("fields constructed with different parameters must have different identifiers")
+	}
+
+	for a := uint32(1); a < 256; a++ {
+		inv, err := aes.Inv(a)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if aes.Mul(a, inv) != 1 {
+			t.Fatalf("%d * %d != 1\n", a, inv)
+		}
+	}
+}
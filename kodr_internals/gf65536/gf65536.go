@@ -0,0 +1,263 @@
+// Arithmetic over GF(2^16), where each element is a 16-bit symbol, serialized
+// as 2 bytes, in big-endian order. Logarithm & exponentiation tables are
+// generated during package initialization, using irreducible polynomial
+// x^16 + x^12 + x^3 + x + 1 ( = 0x1100b ), for which x is a primitive element
+package gf65536
+
+import (
+	crypto_rand "crypto/rand"
+	"encoding/binary"
+	"math/rand"
+
+	"github.com/itzmeanjan/kodr"
+)
+
+// gf65536_ORDER represents the order of the GF(2^16) field
+const gf65536_ORDER = 1 << 16
+
+// gf65536_IRREDUCIBLE_POLYNOMIAL is used for reducing product of two elements
+const gf65536_IRREDUCIBLE_POLYNOMIAL = 0x1100b
+
+// Each element takes these many bytes, when serialized
+const SymbolSize = 2
+
+// gf65536_LOG_TABLE is the logarithm table for GF(2^16)
+var gf65536_LOG_TABLE [gf65536_ORDER]uint16
+
+// gf65536_EXP_TABLE is the exponentiation table for GF(2^16), which is
+// twice as long, so that sum of two logarithms can be looked up without
+// reducing it modulo ( order - 1 )
+var gf65536_EXP_TABLE [2*gf65536_ORDER - 2]uint16
+
+func init() {
+	x := uint32(1)
+	for i := range gf65536_ORDER - 1 {
+		gf65536_EXP_TABLE[i] = uint16(x)
+		gf65536_EXP_TABLE[i+gf65536_ORDER-1] = uint16(x)
+		gf65536_LOG_TABLE[x] = uint16(i)
+
+		x <<= 1
+		if x&gf65536_ORDER != 0 {
+			x ^= gf65536_IRREDUCIBLE_POLYNOMIAL
+		}
+	}
+}
+
+// Gf65536 represents an element in GF(2^16)
+type Gf65536 struct {
+	val uint16
+}
+
+// New creates a new Gf65536 element from a uint16 value
+func New(val uint16) Gf65536 {
+	return Gf65536{val: val}
+}
+
+// Get returns the raw uint16 value of the Gf65536 element
+func (g Gf65536) Get() uint16 {
+	return g.val
+}
+
+// Zero returns the additive identity element (0)
+func Zero() Gf65536 {
+	return Gf65536{val: 0}
+}
+
+// One returns the multiplicative identity element (1)
+func One() Gf65536 {
+	return Gf65536{val: 1}
+}
+
+// PrimitiveElement returns primitive element x for GF(2^16) field
+func PrimitiveElement() Gf65536 {
+	return Gf65536{val: 2}
+}
+
+// Inv computes the multiplicative inverse of the element. Returns error for zero element
+func (g Gf65536) Inv() (Gf65536, error) {
+	if g == Zero() {
+		return Gf65536{}, kodr.ErrCannotInvertGf65536AdditiveIdentity
+	}
+
+	result := Gf65536{
+		val: gf65536_EXP_TABLE[(gf65536_ORDER-1)-int(gf65536_LOG_TABLE[g.val])],
+	}
+	return result, nil
+}
+
+// Add performs addition (XOR) of two Gf65536 elements
+func (g Gf65536) Add(other Gf65536) Gf65536 {
+	return Gf65536{val: g.val ^ other.val}
+}
+
+// AddAssign performs in-place addition (XOR)
+func (g *Gf65536) AddAssign(other Gf65536) {
+	g.val ^= other.val
+}
+
+// Neg computes the additive inverse (returns self as XOR is self-inverse)
+func (g Gf65536) Neg() Gf65536 {
+	return g
+}
+
+// Sub performs subtraction (XOR) of two Gf65536 elements
+func (g Gf65536) Sub(other Gf65536) Gf65536 {
+	return Gf65536{val: g.val ^ other.val}
+}
+
+// Mul performs multiplication of two Gf65536 elements using logarithm and exponentiation tables
+func (g Gf65536) Mul(other Gf65536) Gf65536 {
+	if g == Zero() || other == Zero() {
+		return Zero()
+	}
+
+	l := int(gf65536_LOG_TABLE[g.val])
+	r := int(gf65536_LOG_TABLE[other.val])
+
+	return Gf65536{val: gf65536_EXP_TABLE[l+r]}
+}
+
+// Div performs division of two Gf65536 elements using multiplicative inverse
+func (g Gf65536) Div(other Gf65536) (Gf65536, error) {
+	if inv, err := other.Inv(); err != nil {
+		return Gf65536{}, kodr.ErrCannotInvertGf65536AdditiveIdentity
+	} else {
+		return g.Mul(inv), nil
+	}
+}
+
+// Equal checks for equality between two Gf65536 elements
+func (g Gf65536) Equal(other Gf65536) bool {
+	return g.val == other.val
+}
+
+// Random generates a random Gf65536 element
+func Random() Gf65536 {
+	return Gf65536{val: uint16(rand.Intn(gf65536_ORDER))}
+}
+
+// Reads i-th symbol from a byte slice, holding serialized elements
+func Symbol(buf []byte, i uint) Gf65536 {
+	return Gf65536{val: binary.BigEndian.Uint16(buf[SymbolSize*i:])}
+}
+
+// Writes element as i-th symbol of a byte slice
+func SetSymbol(buf []byte, i uint, g Gf65536) {
+	binary.BigEndian.PutUint16(buf[SymbolSize*i:], g.val)
+}
+
+// MulAddSlice computes dst += c * src, symbol by symbol, where both byte
+// slices hold same #-of serialized elements
+func MulAddSlice(dst, src []byte, c Gf65536) {
+	if c == Zero() {
+		return
+	}
+
+	logc := int(gf65536_LOG_TABLE[c.val])
+	for i := 0; i+1 < len(src); i += SymbolSize {
+		s := binary.BigEndian.Uint16(src[i:])
+		if s == 0 {
+			continue
+		}
+
+		r := gf65536_EXP_TABLE[int(gf65536_LOG_TABLE[s])+logc]
+		d := binary.BigEndian.Uint16(dst[i:])
+		binary.BigEndian.PutUint16(dst[i:], d^r)
+	}
+}
+
+// MulSlice computes dst = c * dst, symbol by symbol
+func MulSlice(dst []byte, c Gf65536) {
+	if c == Zero() {
+		clear(dst)
+		return
+	}
+
+	logc := int(gf65536_LOG_TABLE[c.val])
+	for i := 0; i+1 < len(dst); i += SymbolSize {
+		s := binary.BigEndian.Uint16(dst[i:])
+		if s == 0 {
+			continue
+		}
+
+		binary.BigEndian.PutUint16(dst[i:], gf65536_EXP_TABLE[int(gf65536_LOG_TABLE[s])+logc])
+	}
+}
+
+// Field implements arithmetic over GF(2^16), in a form, encoders
+// & decoders, which are generic over finite field, can use
+type Field struct{}
+
+// DefaultField returns GF(2^16), where each element takes 2 bytes
+func DefaultField() *Field {
+	return &Field{}
+}
+
+// ID identifies field along with its construction parameters, so that
+// pieces coded over different fields can be told apart
+func (f *Field) ID() uint32 {
+	return 16<<24 | uint32(gf65536_IRREDUCIBLE_POLYNOMIAL&0xffff)<<8 | uint32(PrimitiveElement().Get())
+}
+
+// SymbolSize returns #-of bytes taken by each element
+func (f *Field) SymbolSize() uint {
+	return SymbolSize
+}
+
+// Add performs addition (XOR) of two elements
+func (f *Field) Add(a, b uint32) uint32 {
+	return uint32(New(uint16(a)).Add(New(uint16(b))).Get())
+}
+
+// Sub performs subtraction (XOR) of two elements
+func (f *Field) Sub(a, b uint32) uint32 {
+	return uint32(New(uint16(a)).Sub(New(uint16(b))).Get())
+}
+
+// Mul performs multiplication of two elements
+func (f *Field) Mul(a, b uint32) uint32 {
+	return uint32(New(uint16(a)).Mul(New(uint16(b))).Get())
+}
+
+// Inv computes multiplicative inverse, returns error for zero element
+func (f *Field) Inv(a uint32) (uint32, error) {
+	inv, err := New(uint16(a)).Inv()
+	if err != nil {
+		return 0, err
+	}
+	return uint32(inv.Get()), nil
+}
+
+// Random generates a random element
+func (f *Field) Random() uint32 {
+	return uint32(Random().Get())
+}
+
+// MulAddSlice computes dst += c * src, symbol by symbol
+func (f *Field) MulAddSlice(dst, src []byte, c uint32) {
+	MulAddSlice(dst, src, New(uint16(c)))
+}
+
+// MulSlice computes dst = c * dst, symbol by symbol
+func (f *Field) MulSlice(dst []byte, c uint32) {
+	MulSlice(dst, New(uint16(c)))
+}
+
+// Symbol reads i-th element of byte slice
+func (f *Field) Symbol(buf []byte, i uint) uint32 {
+	return uint32(Symbol(buf, i).Get())
+}
+
+// SetSymbol writes i-th element of byte slice
+func (f *Field) SetSymbol(buf []byte, i uint, v uint32) {
+	SetSymbol(buf, i, New(uint16(v)))
+}
+
+// RandomVector generates N random coefficients, drawn from
+// cryptographically secure randomness source, serialized in 2N bytes
+func (f *Field) RandomVector(n uint) []byte {
+	vector := make([]byte, n*SymbolSize)
+	// ignoring error, because it always succeeds
+	crypto_rand.Read(vector)
+	return vector
+}
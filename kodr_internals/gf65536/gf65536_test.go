@@ -0,0 +1,88 @@
+package gf65536_test
+
+import (
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf65536"
+)
+
+// TestGf65536Operations tests the properties of GF(2^16) field operations
+func TestGf65536Operations(t *testing.T) {
+	const numTestIterations = 100_000
+
+	for range numTestIterations {
+		// Generate random Gf65536 elements
+		a := gf65536.Random()
+		b := gf65536.Random()
+
+		// Test Addition, Subtraction, Negation
+		sum := a.Add(b)
+		diff := sum.Sub(b)
+		if !diff.Equal(a) {
+			t.Errorf("Addition/Subtraction property failed: %v - %v != %v", sum, b, a)
+		}
+
+		// Test Multiplication, Division, Inversion
+		mul := a.Mul(b)
+		div, err := mul.Div(b)
+
+		if b == gf65536.Zero() {
+			if err != kodr.ErrCannotInvertGf65536AdditiveIdentity {
+				t.Errorf("Division by zero should return error")
+			}
+		} else {
+			if !div.Equal(a) {
+				t.Errorf("Multiplication/Division property failed: %v / %v != %v", mul, b, a)
+			}
+		}
+	}
+}
+
+// Powers of primitive element must run through all non-zero elements
+func TestGf65536PrimitiveElement(t *testing.T) {
+	seen := make([]bool, 1<<16)
+	x := gf65536.One()
+	for i := range (1 << 16) - 1 {
+		if seen[x.Get()] {
+			t.Fatalf("x^%d repeats an already seen element\n", i)
+		}
+		seen[x.Get()] = true
+		x = x.Mul(gf65536.PrimitiveElement())
+	}
+
+	if x != gf65536.One() {
+		t.Fatal("expected x^(2^16 - 1) = 1")
+	}
+}
+
+func TestGf65536BulkKernels(t *testing.T) {
+	const symbols = 1 << 10
+
+	src := make([]byte, symbols*gf65536.SymbolSize)
+	dst := make([]byte, symbols*gf65536.SymbolSize)
+	for i := range uint(symbols) {
+		gf65536.SetSymbol(src, i, gf65536.Random())
+		gf65536.SetSymbol(dst, i, gf65536.Random())
+	}
+
+	c := gf65536.Random()
+	expected := make([]gf65536.Gf65536, symbols)
+	for i := range uint(symbols) {
+		expected[i] = gf65536.Symbol(dst, i).Add(gf65536.Symbol(src, i).Mul(c))
+	}
+
+	gf65536.MulAddSlice(dst, src, c)
+	for i := range uint(symbols) {
+		if gf65536.Symbol(dst, i) != expected[i] {
+			t.Fatalf("MulAddSlice mismatch at symbol %d\n", i)
+		}
+	}
+
+	gf65536.MulSlice(dst, c)
+	for i := range uint(symbols) {
+		if gf65536.Symbol(dst, i) != expected[i].Mul(c) {
+			t.Fatalf("MulSlice mismatch at symbol %d\n", i)
+		}
+	}
+}
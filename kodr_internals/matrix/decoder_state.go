@@ -1,135 +1,124 @@
 package matrix
 
 import (
+	"slices"
+
 	"github.com/itzmeanjan/kodr"
 	"github.com/itzmeanjan/kodr/kodr_internals"
-	"github.com/itzmeanjan/kodr/kodr_internals/gf256"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf65536"
 )
 
 type DecoderState struct {
 	pieceCount uint
+	field      field.Field
 	coeffs     Matrix
 	coded      Matrix
 }
 
+// Coding coefficient at given row & column, where each
+// column is one symbol wide
+func (d *DecoderState) coeff(row, col int) uint32 {
+	return d.field.Symbol(d.coeffs[row], uint(col))
+}
+
+func (d *DecoderState) div(a, b uint32) uint32 {
+	inv, _ := d.field.Inv(b)
+	return d.field.Mul(a, inv)
+}
+
+// #-of columns in coefficient matrix i.e. #-of symbols in each row
+func (d *DecoderState) cols() int {
+	return len(d.coeffs[0]) / int(d.field.SymbolSize())
+}
+
+// Brings coefficient matrix to row echelon form, where pivot of each row
+// is searched in leftmost column, which still has a nonzero coefficient
+// in some row below, so that columns without pivot are skipped, instead
+// of leaving a row unpivoted
 func (d *DecoderState) clean_forward() {
 	var (
-		rows     int = int(d.coeffs.Rows())
-		cols     int = int(d.coeffs.Cols())
-		boundary int = min(rows, cols)
+		rows  int = int(d.coeffs.Rows())
+		cols  int = d.cols()
+		width int = int(d.field.SymbolSize())
+		pivot int = 0
 	)
 
-	for i := range boundary {
-		if d.coeffs[i][i] == 0 {
-			non_zero_col := false
-			pivot := i + 1
-			for ; pivot < rows; pivot++ {
-				if d.coeffs[pivot][i] != 0 {
-					non_zero_col = true
-					break
-				}
-			}
-
-			if !non_zero_col {
-				continue
-			}
-
-			// row switching in coefficient matrix
-			{
-				tmp := d.coeffs[i]
-				d.coeffs[i] = d.coeffs[pivot]
-				d.coeffs[pivot] = tmp
-			}
-			// row switching in coded piece matrix
-			{
-				tmp := d.coded[i]
-				d.coded[i] = d.coded[pivot]
-				d.coded[pivot] = tmp
+	for i := 0; i < cols && pivot < rows; i++ {
+		non_zero_row := -1
+		for j := pivot; j < rows; j++ {
+			if d.coeff(j, i) != 0 {
+				non_zero_row = j
+				break
 			}
 		}
 
-		for j := i + 1; j < rows; j++ {
-			if d.coeffs[j][i] == 0 {
-				continue
-			}
-
-			quotient, _ := gf256.New(d.coeffs[j][i]).Div(gf256.New(d.coeffs[i][i]))
-			for k := i; k < cols; k++ {
-				res := gf256.New(d.coeffs[j][k])
+		if non_zero_row < 0 {
+			continue
+		}
 
-				l := gf256.New(d.coeffs[i][k])
-				res.AddAssign(l.Mul(quotient))
+		// row switching in both coefficient & coded piece matrix
+		d.coeffs[pivot], d.coeffs[non_zero_row] = d.coeffs[non_zero_row], d.coeffs[pivot]
+		d.coded[pivot], d.coded[non_zero_row] = d.coded[non_zero_row], d.coded[pivot]
 
-				d.coeffs[j][k] = res.Get()
+		for j := pivot + 1; j < rows; j++ {
+			if d.coeff(j, i) == 0 {
+				continue
 			}
 
-			for k := 0; k < len(d.coded[0]); k++ {
-				res := gf256.New(d.coded[j][k])
+			// row_j -= quotient * row_pivot
+			quotient := d.field.Sub(0, d.div(d.coeff(j, i), d.coeff(pivot, i)))
+			d.field.MulAddSlice(d.coeffs[j][i*width:], d.coeffs[pivot][i*width:], quotient)
+			d.field.MulAddSlice(d.coded[j], d.coded[pivot], quotient)
+		}
 
-				l := gf256.New(d.coded[i][k])
-				res.AddAssign(l.Mul(quotient))
+		pivot++
+	}
+}
 
-				d.coded[j][k] = res.Get()
-			}
+// Column of first nonzero coefficient in row, -1 if it's a zero row
+func (d *DecoderState) lead(row int) int {
+	for i := range d.cols() {
+		if d.coeff(row, i) != 0 {
+			return i
 		}
 	}
+	return -1
 }
 
+// Eliminates pivot column of each row, from all rows above it, while
+// making pivot 1, so that coefficient matrix is in reduced row echelon form
 func (d *DecoderState) clean_backward() {
 	var (
-		rows     int = int(d.coeffs.Rows())
-		cols     int = int(d.coeffs.Cols())
-		boundary int = min(rows, cols)
+		rows  int = int(d.coeffs.Rows())
+		width int = int(d.field.SymbolSize())
 	)
 
-	for i := boundary - 1; i >= 0; i-- {
-		if d.coeffs[i][i] == 0 {
+	for i := rows - 1; i >= 0; i-- {
+		lead := d.lead(i)
+		if lead < 0 {
 			continue
 		}
 
 		for j := 0; j < i; j++ {
-			if d.coeffs[j][i] == 0 {
+			if d.coeff(j, lead) == 0 {
 				continue
 			}
 
-			quotient, _ := gf256.New(d.coeffs[j][i]).Div(gf256.New(d.coeffs[i][i]))
-			for k := i; k < cols; k++ {
-				res := gf256.New(d.coeffs[j][k])
-
-				l := gf256.New(d.coeffs[i][k])
-				res.AddAssign(l.Mul(quotient))
-
-				d.coeffs[j][k] = res.Get()
-			}
-
-			for k := 0; k < len(d.coded[0]); k++ {
-				res := gf256.New(d.coded[j][k])
-
-				l := gf256.New(d.coded[i][k])
-				res.AddAssign(l.Mul(quotient))
-
-				d.coded[j][k] = res.Get()
-			}
-
+			// row_j -= quotient * row_i
+			quotient := d.field.Sub(0, d.div(d.coeff(j, lead), d.coeff(i, lead)))
+			d.field.MulAddSlice(d.coeffs[j][lead*width:], d.coeffs[i][lead*width:], quotient)
+			d.field.MulAddSlice(d.coded[j], d.coded[i], quotient)
 		}
 
-		if d.coeffs[i][i] == 1 {
+		if d.coeff(i, lead) == 1 {
 			continue
 		}
 
-		inv, _ := gf256.New(d.coeffs[i][i]).Inv()
-		d.coeffs[i][i] = 1
-		for j := i + 1; j < cols; j++ {
-			if d.coeffs[i][j] == 0 {
-				continue
-			}
-
-			d.coeffs[i][j] = gf256.New(d.coeffs[i][j]).Mul(inv).Get()
-		}
-
-		for j := 0; j < len(d.coded[0]); j++ {
-			d.coded[i][j] = gf256.New(d.coded[i][j]).Mul(inv).Get()
-		}
+		inv, _ := d.field.Inv(d.coeff(i, lead))
+		d.field.SetSymbol(d.coeffs[i], uint(lead), 1)
+		d.field.MulSlice(d.coeffs[i][(lead+1)*width:], inv)
+		d.field.MulSlice(d.coded[i], inv)
 	}
 }
 
@@ -188,6 +177,44 @@ func (d *DecoderState) Rank() uint {
 	return d.coeffs.Rows()
 }
 
+// Finite field, coding coefficients & coded pieces are elements of
+func (d *DecoderState) Field() field.Field {
+	return d.field
+}
+
+// Length of each coded piece, in bytes, 0 if none added yet
+func (d *DecoderState) PieceLength() uint {
+	if len(d.coded) == 0 {
+		return 0
+	}
+	return d.coded.Cols()
+}
+
+// Summary of subspace spanned by received coding vectors, holding copy
+// of RREF coefficient matrix. Expected to be invoked after RREF-ed,
+// though a single row needn't be
+func (d *DecoderState) Summary() *Summary {
+	basis := make(Matrix, 0, len(d.coeffs))
+	pivots := make([]uint, 0, len(d.coeffs))
+	for i := range d.coeffs {
+		lead := d.lead(i)
+		if lead < 0 {
+			continue
+		}
+
+		row := make([]byte, len(d.coeffs[i]))
+		copy(row, d.coeffs[i])
+		if c := d.coeff(i, lead); c != 1 {
+			inv, _ := d.field.Inv(c)
+			d.field.MulSlice(row, inv)
+		}
+		basis = append(basis, row)
+		pivots = append(pivots, uint(lead))
+	}
+
+	return &Summary{pieceCount: d.pieceCount, field: d.field, pivots: pivots, basis: basis}
+}
+
 // Current state of coding coefficient matrix
 func (d *DecoderState) CoefficientMatrix() Matrix {
 	return d.coeffs
@@ -207,6 +234,65 @@ func (d *DecoderState) AddPiece(codedPiece *kodr_internals.CodedPiece) {
 	d.coded = append(d.coded, codedPiece.Piece)
 }
 
+// Absorbs all rows of other decoder state, which must be decoding same
+// #-of pieces, over same finite field, so that both bases & coded pieces
+// get merged, followed by single RREF. Other decoder state's rows are
+// copied, so it's left untouched & can still be used
+func (d *DecoderState) Merge(other *DecoderState) error {
+	if d.pieceCount != other.pieceCount || d.field.ID() != other.field.ID() {
+		return kodr.ErrDecoderMismatch
+	}
+	if d.PieceLength() != 0 && other.PieceLength() != 0 && d.PieceLength() != other.PieceLength() {
+		return kodr.ErrDecoderMismatch
+	}
+	if len(other.coeffs) == 0 {
+		return nil
+	}
+
+	for i := range other.coeffs {
+		vector := make(kodr_internals.CodingVector, len(other.coeffs[i]))
+		copy(vector, other.coeffs[i])
+		piece := make(kodr_internals.Piece, len(other.coded[i]))
+		copy(piece, other.coded[i])
+
+		d.coeffs = append(d.coeffs, vector)
+		d.coded = append(d.coded, piece)
+	}
+
+	d.Rref()
+	return nil
+}
+
+// Column of only nonzero coefficient in row, if it's a unit vector
+// ( up to scaling ), otherwise -1
+func (d *DecoderState) unit(row int) int {
+	col := -1
+	for i := range d.cols() {
+		if d.coeff(row, i) == 0 {
+			continue
+		}
+		if col >= 0 {
+			return -1
+		}
+		col = i
+	}
+	return col
+}
+
+// Indices of original pieces, which are decodable right now i.e. whose
+// row in RREF coefficient matrix is a unit vector, in increasing order
+func (d *DecoderState) Decodable() []uint {
+	decodable := make([]uint, 0, len(d.coeffs))
+	for i := range d.coeffs {
+		if col := d.unit(i); col >= 0 {
+			decodable = append(decodable, uint(col))
+		}
+	}
+
+	slices.Sort(decodable)
+	return decodable
+}
+
 // Request decoded piece by index ( 0 based, definitely )
 //
 // If piece not yet decoded/ requested index is >= #-of
@@ -214,6 +300,9 @@ func (d *DecoderState) AddPiece(codedPiece *kodr_internals.CodedPiece) {
 //
 // # Otherwise piece is returned, without any error
 //
+// Piece is decoded, iff some row of coefficient matrix is a unit vector,
+// having its only nonzero coefficient at requested index
+//
 // Note: This method will copy decoded piece into newly allocated memory
 // when whole decoding hasn't yet happened, to prevent any chance
 // that user mistakenly modifies slice returned ( read piece )
@@ -222,50 +311,49 @@ func (d *DecoderState) GetPiece(idx uint) (kodr_internals.Piece, error) {
 	if idx >= d.pieceCount {
 		return nil, kodr.ErrPieceOutOfBound
 	}
-	if idx >= d.coeffs.Rows() {
-		return nil, kodr.ErrPieceNotDecodedYet
-	}
 
 	if d.Rank() >= d.pieceCount {
 		return d.coded[idx], nil
 	}
 
-	cols := int(d.coeffs.Cols())
-	decoded := true
-
-OUT:
-	for i := range cols {
-		switch i {
-		case int(idx):
-			if d.coeffs[idx][i] != 1 {
-				decoded = false
-				break OUT
-			}
+	for i := range d.coeffs {
+		if d.unit(i) != int(idx) {
+			continue
+		}
 
-		default:
-			if d.coeffs[idx][i] == 0 {
-				decoded = false
-				break OUT
-			}
+		buf := make([]byte, d.coded.Cols())
+		copy(buf, d.coded[i])
 
+		// a single row, which isn't yet rref-ed, may not be normalized
+		if c := d.coeff(i, int(idx)); c != 1 {
+			inv, _ := d.field.Inv(c)
+			d.field.MulSlice(buf, inv)
 		}
+		return buf, nil
 	}
 
-	if !decoded {
-		return nil, kodr.ErrPieceNotDecodedYet
-	}
-
-	buf := make([]byte, d.coded.Cols())
-	copy(buf, d.coded[idx])
-	return buf, nil
+	return nil, kodr.ErrPieceNotDecodedYet
 }
 
 func NewDecoderStateWithPieceCount(pieceCount uint) *DecoderState {
+	return NewDecoderStateWithField(pieceCount, field.Default())
+}
+
+func NewDecoderState(coeffs, coded Matrix) *DecoderState {
+	return &DecoderState{pieceCount: uint(len(coeffs)), field: field.Default(), coeffs: coeffs, coded: coded}
+}
+
+// Decoder state, where coding coefficients & coded pieces are
+// interpreted as sequence of elements of given finite field
+func NewDecoderStateWithField(pieceCount uint, f field.Field) *DecoderState {
 	coeffs := make([][]byte, 0, pieceCount)
 	coded := make([][]byte, 0, pieceCount)
-	return &DecoderState{pieceCount: pieceCount, coeffs: coeffs, coded: coded}
+	return &DecoderState{pieceCount: pieceCount, field: f, coeffs: coeffs, coded: coded}
 }
 
-func NewDecoderState(coeffs, coded Matrix) *DecoderState {
-	return &DecoderState{pieceCount: uint(len(coeffs)), coeffs: coeffs, coded: coded}
+// Decoder state, where coding coefficients are GF(2^16) elements i.e.
+// each coefficient takes 2 bytes & coded pieces are also interpreted
+// as sequence of 16-bit symbols
+func NewGf65536DecoderStateWithPieceCount(pieceCount uint) *DecoderState {
+	return NewDecoderStateWithField(pieceCount, gf65536.DefaultField())
 }
@@ -0,0 +1,162 @@
+package matrix
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+)
+
+// Decides which cached inverse to drop, when cache is full
+type EvictionPolicy uint8
+
+const (
+	// Drops least recently used inverse
+	EvictLRU EvictionPolicy = iota
+	// Drops oldest inserted inverse, lookups don't refresh entries
+	EvictFIFO
+)
+
+type cacheEntry struct {
+	key     string
+	inverse Matrix
+}
+
+// Cache of decoding matrices, keyed by set of received coding vectors,
+// so that many generations, coded with same coefficients ( say seeded )
+// & experiencing same erasure pattern, need to perform
+// elimination only once
+//
+// Safe for concurrent use by decoders of different generations
+type InverseCache struct {
+	lock     sync.Mutex
+	capacity uint
+	policy   EvictionPolicy
+	entries  map[string]*list.Element
+	order    *list.List
+	hits     uint64
+	misses   uint64
+}
+
+// #-of decoding matrices currently cached
+func (c *InverseCache) Len() uint {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return uint(c.order.Len())
+}
+
+// #-of successful & unsuccessful lookups, so far
+func (c *InverseCache) Stats() (hits uint64, misses uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.hits, c.misses
+}
+
+// Looks up decoding matrix, computed for given key
+func (c *InverseCache) Get(key string) (Matrix, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	elm, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+
+	c.hits++
+	if c.policy == EvictLRU {
+		c.order.MoveToFront(elm)
+	}
+	return elm.Value.(*cacheEntry).inverse, true
+}
+
+// Remembers decoding matrix for given key, evicting some other
+// entry, following configured policy, if cache is already full
+func (c *InverseCache) Put(key string, inverse Matrix) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.capacity == 0 {
+		return
+	}
+
+	if elm, ok := c.entries[key]; ok {
+		elm.Value.(*cacheEntry).inverse = inverse
+		if c.policy == EvictLRU {
+			c.order.MoveToFront(elm)
+		}
+		return
+	}
+
+	if uint(c.order.Len()) >= c.capacity {
+		last := c.order.Back()
+		c.order.Remove(last)
+		delete(c.entries, last.Value.(*cacheEntry).key)
+	}
+
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, inverse: inverse})
+}
+
+// Cache holding at max `capacity` many decoding matrices, with
+// specified eviction policy
+func NewInverseCache(capacity uint, policy EvictionPolicy) *InverseCache {
+	return &InverseCache{
+		capacity: capacity,
+		policy:   policy,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Computes decoding matrix T ( of dimension pieceCount x len(coeffs) ) for
+// received coding vectors, such that T x coded pieces = original pieces
+//
+// It's done by rref-ing coefficient matrix while applying same row
+// operations on identity matrix, using DecoderState. Original coding
+// vectors are kept untouched. If received coding vectors are not
+// of full rank, returns error indicating more pieces are required
+func DecodingMatrix(coeffs Matrix, pieceCount uint) (Matrix, error) {
+	return DecodingMatrixWithField(field.Default(), coeffs, pieceCount)
+}
+
+// Same as `DecodingMatrix`, but coding coefficients are elements of given
+// finite field, so is the decoding matrix
+func DecodingMatrixWithField(f field.Field, coeffs Matrix, pieceCount uint) (Matrix, error) {
+	rows := coeffs.Rows()
+	if rows < pieceCount {
+		return nil, kodr.ErrMoreUsefulPiecesRequired
+	}
+
+	coeffs_ := make(Matrix, rows)
+	identity := make(Matrix, rows)
+	for i := range rows {
+		coeffs_[i] = make([]byte, len(coeffs[i]))
+		copy(coeffs_[i], coeffs[i])
+
+		identity[i] = make([]byte, rows*f.SymbolSize())
+		f.SetSymbol(identity[i], i, 1)
+	}
+
+	state := NewDecoderStateWithField(pieceCount, f)
+	state.coeffs = coeffs_
+	state.coded = identity
+	state.Rref()
+	if state.Rank() != pieceCount {
+		return nil, kodr.ErrMoreUsefulPiecesRequired
+	}
+
+	// reduced coefficient matrix must be identity, only then
+	// decoding matrix is ready
+	for i := range int(pieceCount) {
+		for j := range int(pieceCount) {
+			if v := state.coeff(i, j); (i == j && v != 1) || (i != j && v != 0) {
+				return nil, kodr.ErrMoreUsefulPiecesRequired
+			}
+		}
+	}
+
+	return state.CodedPieceMatrix(), nil
+}
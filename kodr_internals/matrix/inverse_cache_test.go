@@ -0,0 +1,81 @@
+package matrix_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals/matrix"
+)
+
+func TestInverseCacheEviction(t *testing.T) {
+	inverse := matrix.Matrix{{1}}
+
+	t.Run("LRU", func(t *testing.T) {
+		cache := matrix.NewInverseCache(2, matrix.EvictLRU)
+		cache.Put("a", inverse)
+		cache.Put("b", inverse)
+		// refreshes `a`, so `b` is to be evicted next
+		if _, ok := cache.Get("a"); !ok {
+			t.Fatal("expected cache hit")
+		}
+		cache.Put("c", inverse)
+
+		if _, ok := cache.Get("b"); ok {
+			t.Fatal("expected `b` to be evicted")
+		}
+		if _, ok := cache.Get("a"); !ok {
+			t.Fatal("expected `a` to be cached")
+		}
+		if hits, misses := cache.Stats(); hits != 2 || misses != 1 {
+			t.Fatalf("expected 2 hits & 1 miss, found %d & %d\n", hits, misses)
+		}
+	})
+
+	t.Run("FIFO", func(t *testing.T) {
+		cache := matrix.NewInverseCache(2, matrix.EvictFIFO)
+		cache.Put("a", inverse)
+		cache.Put("b", inverse)
+		cache.Get("a")
+		cache.Put("c", inverse)
+
+		if _, ok := cache.Get("a"); ok {
+			t.Fatal("expected `a` to be evicted")
+		}
+		if cache.Len() != 2 {
+			t.Fatalf("expected 2 cached entries, found %d\n", cache.Len())
+		}
+	})
+
+	t.Run("ZeroCapacity", func(t *testing.T) {
+		cache := matrix.NewInverseCache(0, matrix.EvictLRU)
+		cache.Put("a", inverse)
+		if cache.Len() != 0 {
+			t.Fatal("expected nothing to be cached")
+		}
+	})
+}
+
+func TestDecodingMatrix(t *testing.T) {
+	// 5 coding vectors over 4 pieces, of rank 4
+	coeffs := matrix.Matrix{{68, 54, 6, 230}, {70, 137, 2, 152}, {16, 56, 215, 78}, {159, 186, 146, 163}, {122, 41, 205, 133}}
+	identity := matrix.Matrix{{1, 0, 0, 0}, {0, 1, 0, 0}, {0, 0, 1, 0}, {0, 0, 0, 1}}
+
+	dec, err := matrix.DecodingMatrix(coeffs, 4)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	mult, err := dec.Multiply(coeffs)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !mult.Cmp(identity) {
+		t.Fatal("decoding matrix x coding vectors != identity")
+	}
+
+	deficient := matrix.Matrix{{70, 137, 2, 152}, {223, 92, 234, 98}, {217, 141, 33, 44}, {145, 135, 71, 45}}
+	if _, err := matrix.DecodingMatrix(deficient, 4); !(err != nil && errors.Is(err, kodr.ErrMoreUsefulPiecesRequired)) {
+		t.Fatalf("expected: %s\n", kodr.ErrMoreUsefulPiecesRequired)
+	}
+}
@@ -2,6 +2,7 @@ package matrix
 
 import (
 	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
 	"github.com/itzmeanjan/kodr/kodr_internals/gf256"
 )
 
@@ -68,3 +69,54 @@ func (m *Matrix) Multiply(with Matrix) (Matrix, error) {
 
 	return mult, nil
 }
+
+// Multiplies two matrices ( which can be multiplied ) in order `m x with`,
+// where each cell is an element of given finite field, taking
+// `f.SymbolSize()` bytes, so #-of columns = row length / symbol size
+func (m *Matrix) MultiplyWithField(f field.Field, with Matrix) (Matrix, error) {
+	width := f.SymbolSize()
+	cols := m.Cols() / width
+	if cols != with.Rows() {
+		return nil, kodr.ErrMatrixDimensionMismatch
+	}
+
+	mult := make([][]byte, m.Rows())
+	for i := range m.Rows() {
+		mult[i] = make([]byte, with.Cols())
+		for k := range cols {
+			f.MulAddSlice(mult[i], with[k], f.Symbol((*m)[i], k))
+		}
+	}
+
+	return mult, nil
+}
+
+// Computes inverse of a square matrix, by rref-ing it
+// while applying same row operations on identity matrix
+//
+// Original matrix is kept untouched, if it's singular
+// ( or not square ) returns error indicating so
+func (m *Matrix) Inverse() (Matrix, error) {
+	if m.Rows() != m.Cols() {
+		return nil, kodr.ErrMatrixDimensionMismatch
+	}
+
+	dim := m.Rows()
+	coeffs := make(Matrix, dim)
+	identity := make(Matrix, dim)
+	for i := range dim {
+		coeffs[i] = make([]byte, dim)
+		copy(coeffs[i], (*m)[i])
+
+		identity[i] = make([]byte, dim)
+		identity[i][i] = 1
+	}
+
+	state := NewDecoderState(coeffs, identity)
+	state.Rref()
+	if state.Rank() < dim {
+		return nil, kodr.ErrSingularMatrix
+	}
+
+	return state.CodedPieceMatrix(), nil
+}
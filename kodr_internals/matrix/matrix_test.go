@@ -3,9 +3,12 @@ package matrix_test
 import (
 	"bytes"
 	"errors"
+	"slices"
 	"testing"
 
 	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
 	"github.com/itzmeanjan/kodr/kodr_internals/matrix"
 )
 
@@ -84,6 +87,22 @@ func TestMatrixRank(t *testing.T) {
 			t.Fatalf("expected rank 5, received %d", rank)
 		}
 	}
+
+	{
+		// no pivot on diagonal, while last column has dependent rows
+		m := matrix.Matrix{{0, 0, 1}, {0, 0, 1}}
+		m_rref := matrix.Matrix{{0, 0, 1}}
+		coded := matrix.Matrix{{0, 0, 0}, {0, 0, 0}}
+
+		dec := matrix.NewDecoderState(m, coded)
+		dec.Rref()
+		if rank := dec.Rank(); rank != 1 {
+			t.Fatalf("expected rank 1, received %d", rank)
+		}
+		if res := dec.CoefficientMatrix(); !res.Cmp(m_rref) {
+			t.Fatal("rref doesn't match !")
+		}
+	}
 }
 
 func TestMatrixMultiplication(t *testing.T) {
@@ -107,3 +126,77 @@ func TestMatrixMultiplication(t *testing.T) {
 		}
 	}
 }
+
+func TestMatrixInverse(t *testing.T) {
+	m := matrix.Matrix{{68, 54, 6, 230}, {16, 56, 215, 78}, {159, 186, 146, 163}, {122, 41, 205, 133}}
+	identity := matrix.Matrix{{1, 0, 0, 0}, {0, 1, 0, 0}, {0, 0, 1, 0}, {0, 0, 0, 1}}
+
+	inv, err := m.Inverse()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	mult, err := m.Multiply(inv)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !mult.Cmp(identity) {
+		t.Fatal("m x inverse(m) != identity")
+	}
+
+	singular := matrix.Matrix{{70, 137, 2, 152}, {223, 92, 234, 98}, {217, 141, 33, 44}, {145, 135, 71, 45}}
+	if _, err := singular.Inverse(); !(err != nil && errors.Is(err, kodr.ErrSingularMatrix)) {
+		t.Fatal("expected singular matrix error indication")
+	}
+}
+
+// Pieces are decodable, iff their row in RREF coefficient matrix is
+// a unit vector, which needn't be at same row index as piece index,
+// while rank is short of piece count
+func TestDecoderStateDecodable(t *testing.T) {
+	f := field.Default()
+	for _, state := range []matrix.State{matrix.NewDecoderStateWithPieceCount(8), matrix.NewSparseDecoderStateWithPieceCount(8)} {
+		pieces := randomPieces(8, 32)
+
+		add := func(coeffs map[int]uint32) {
+			vector := make(kodr_internals.CodingVector, 8)
+			piece := make(kodr_internals.Piece, 32)
+			for i, c := range coeffs {
+				f.SetSymbol(vector, uint(i), c)
+				f.MulAddSlice(piece, pieces[i], c)
+			}
+
+			state.AddPiece(&kodr_internals.CodedPiece{Vector: vector, Piece: piece})
+			state.Rref()
+		}
+
+		add(map[int]uint32{0: 1, 1: 1, 2: 1, 3: 1, 4: 1, 5: 1, 6: 1, 7: 1})
+		if decodable := state.Decodable(); len(decodable) != 0 {
+			t.Fatalf("expected nothing decodable, found %v\n", decodable)
+		}
+		if _, err := state.GetPiece(0); !errors.Is(err, kodr.ErrPieceNotDecodedYet) {
+			t.Fatalf("expected: %s\n", kodr.ErrPieceNotDecodedYet)
+		}
+
+		add(map[int]uint32{6: 7})
+		add(map[int]uint32{3: 2, 6: 9})
+		if decodable := state.Decodable(); !slices.Equal(decodable, []uint{3, 6}) {
+			t.Fatalf("expected decodable pieces [3 6], found %v\n", decodable)
+		}
+
+		for _, i := range []uint{3, 6} {
+			piece, err := state.GetPiece(i)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if !bytes.Equal(piece, pieces[i]) {
+				t.Fatal("decoded data doesn't match !")
+			}
+		}
+		for _, i := range []uint{0, 1, 2} {
+			if _, err := state.GetPiece(i); !errors.Is(err, kodr.ErrPieceNotDecodedYet) {
+				t.Fatalf("expected: %s\n", kodr.ErrPieceNotDecodedYet)
+			}
+		}
+	}
+}
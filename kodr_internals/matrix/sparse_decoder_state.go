@@ -0,0 +1,474 @@
+package matrix
+
+import (
+	"slices"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf65536"
+)
+
+// Nonzero coefficient of sparse row, at given column
+type sparseEntry struct {
+	col   uint
+	coeff uint32
+}
+
+// Role of a row in sparse decoder state
+const (
+	// Has >= 2 free columns, waiting for those to be pivoted/ inactivated
+	rowPending = iota
+	// Pivot column's coefficient is 1, other nonzero coefficients
+	// are only at inactivated, not yet resolved columns
+	rowPivot
+	// Nonzero coefficients are only at inactivated, not yet resolved
+	// columns, to be solved densely
+	rowDense
+	// Linearly dependent, carries no information
+	rowDropped
+)
+
+type sparseRow struct {
+	entries []sparseEntry
+	piece   kodr_internals.Piece
+	degree  uint
+	role    int
+}
+
+// SparseDecoderState - Decoder state for sparse coding vectors, which
+// stores only nonzero coefficients & avoids fill-in, by inactivation
+// decoding. Rows having a single free ( i.e. neither pivoted nor
+// inactivated ) column are pivoted on it, which reveals more such rows.
+// When that stalls, row with fewest free columns is picked greedily, all
+// but one of its free columns are inactivated ( most connected ones
+// first ) & it's pivoted on remaining one. Inactivated columns are
+// finally solved densely, using `DecoderState`, which is small, as long
+// as coding vectors are sparse, then substituted back into pivot rows
+//
+// Inactivation is deferred until collected rows are enough to reach
+// full rank, because inactivating early ( with few rows ) makes most
+// columns inactive. Until then, rows still waiting for free columns
+// aren't counted in `Rank`, which is why it's a lower bound
+type SparseDecoderState struct {
+	pieceCount  uint
+	field       field.Field
+	pieceLength uint
+	rows        []*sparseRow
+	// Pivot row of column, -1 if none
+	pivotOf []int
+	// Column is inactivated, but not yet resolved
+	inactive []bool
+	// Free column -> pending rows, it's part of
+	adjacency    [][]int
+	ripple       []int
+	pending      []int
+	pendingCount uint
+	pivotRows    []int
+	dense        []int
+	dirty        bool
+	pivots       uint
+	inactivated  uint
+	// Sparse accumulator, for row operations
+	scratch []uint32
+	marked  []bool
+	touched []uint
+}
+
+// Column is neither pivoted nor inactivated
+func (s *SparseDecoderState) free(col uint) bool {
+	return s.pivotOf[col] < 0 && !s.inactive[col]
+}
+
+func (s *SparseDecoderState) accumulate(col uint, v uint32) {
+	if !s.marked[col] {
+		s.marked[col] = true
+		s.scratch[col] = 0
+		s.touched = append(s.touched, col)
+	}
+	s.scratch[col] = s.field.Add(s.scratch[col], v)
+}
+
+// Moves accumulated nonzero coefficients into row, clearing accumulator
+func (s *SparseDecoderState) store(row *sparseRow) {
+	entries := make([]sparseEntry, 0, len(s.touched))
+	for _, col := range s.touched {
+		if s.scratch[col] != 0 {
+			entries = append(entries, sparseEntry{col: col, coeff: s.scratch[col]})
+		}
+		s.marked[col] = false
+	}
+	s.touched = s.touched[:0]
+	row.entries = entries
+}
+
+// Substitutes pivot rows for all pivoted columns of row, other than its
+// own pivot column. As pivot rows have nonzero coefficients only at their
+// pivot & inactivated columns, a single pass is enough
+func (s *SparseDecoderState) reduce(row *sparseRow) {
+	substitute := false
+	for _, e := range row.entries {
+		if p := s.pivotOf[e.col]; p >= 0 && s.rows[p] != row {
+			substitute = true
+			break
+		}
+	}
+	if !substitute {
+		return
+	}
+
+	for _, e := range row.entries {
+		s.accumulate(e.col, e.coeff)
+	}
+	for _, e := range row.entries {
+		p := s.pivotOf[e.col]
+		if p < 0 || s.rows[p] == row {
+			continue
+		}
+
+		// row -= coeff * pivot_row, where pivot row's coefficient at
+		// pivot column is 1
+		q := s.field.Sub(0, e.coeff)
+		for _, pe := range s.rows[p].entries {
+			s.accumulate(pe.col, s.field.Mul(q, pe.coeff))
+		}
+		s.field.MulAddSlice(row.piece, s.rows[p].piece, q)
+	}
+	s.store(row)
+}
+
+// Free column is pivoted or inactivated, so it's no more counted
+// in pending rows it's part of
+func (s *SparseDecoderState) release(col uint) {
+	for _, r := range s.adjacency[col] {
+		row := s.rows[r]
+		if row.role != rowPending {
+			continue
+		}
+
+		row.degree--
+		if row.degree == 1 {
+			s.ripple = append(s.ripple, r)
+		}
+	}
+	s.adjacency[col] = nil
+}
+
+// Makes row at index `r` pivot row of column `col`, normalizing
+// its coefficient to 1
+func (s *SparseDecoderState) pivot(r int, col uint) {
+	row := s.rows[r]
+	for _, e := range row.entries {
+		if e.col != col || e.coeff == 1 {
+			continue
+		}
+
+		inv, _ := s.field.Inv(e.coeff)
+		for k := range row.entries {
+			row.entries[k].coeff = s.field.Mul(row.entries[k].coeff, inv)
+		}
+		s.field.MulSlice(row.piece, inv)
+		break
+	}
+
+	row.role = rowPivot
+	s.pivotOf[col] = r
+	s.pivotRows = append(s.pivotRows, r)
+	s.pivots++
+}
+
+// Keeps pivoting rows having at most one free column, until none is left
+func (s *SparseDecoderState) peel() {
+	for len(s.ripple) > 0 {
+		r := s.ripple[len(s.ripple)-1]
+		s.ripple = s.ripple[:len(s.ripple)-1]
+
+		row := s.rows[r]
+		if row.role != rowPending {
+			continue
+		}
+		s.reduce(row)
+
+		count, col := uint(0), uint(0)
+		for _, e := range row.entries {
+			if s.free(e.col) {
+				count++
+				col = e.col
+			}
+		}
+
+		switch count {
+		case 0:
+			s.pendingCount--
+			if len(row.entries) == 0 {
+				row.role, row.piece = rowDropped, nil
+				continue
+			}
+
+			row.role = rowDense
+			s.dense = append(s.dense, r)
+			s.dirty = true
+
+		case 1:
+			s.pendingCount--
+			s.pivot(r, col)
+			s.release(col)
+
+		default:
+			row.degree = count
+		}
+	}
+}
+
+// Greedily picks pending row with fewest free columns & inactivates all
+// but one of them, so that it can be pivoted, until no row is pending
+func (s *SparseDecoderState) inactivate() {
+	for s.pendingCount > 0 {
+		pending := s.pending[:0]
+		best := -1
+		for _, r := range s.pending {
+			if s.rows[r].role != rowPending {
+				continue
+			}
+
+			pending = append(pending, r)
+			if best < 0 || s.rows[r].degree < s.rows[best].degree {
+				best = r
+			}
+		}
+		s.pending = pending
+
+		cols := make([]uint, 0, s.rows[best].degree)
+		for _, e := range s.rows[best].entries {
+			if s.free(e.col) {
+				cols = append(cols, e.col)
+			}
+		}
+		slices.SortFunc(cols, func(a, b uint) int {
+			return len(s.adjacency[b]) - len(s.adjacency[a])
+		})
+
+		// already pivotable, only needs another peeling pass
+		if len(cols) < 2 {
+			s.ripple = append(s.ripple, best)
+			s.peel()
+			continue
+		}
+		for _, col := range cols[:len(cols)-1] {
+			s.inactive[col] = true
+			s.inactivated++
+			s.release(col)
+		}
+		s.peel()
+	}
+}
+
+// Solves rows over inactivated columns densely & substitutes resolved
+// columns back into pivot rows
+func (s *SparseDecoderState) solve() {
+	if !s.dirty {
+		return
+	}
+	s.dirty = false
+
+	column := make([]uint, s.pieceCount)
+	cols := make([]uint, 0)
+	for col := range s.pieceCount {
+		if s.inactive[col] {
+			column[col] = uint(len(cols))
+			cols = append(cols, col)
+		}
+	}
+
+	width := s.field.SymbolSize()
+	state := NewDecoderStateWithField(uint(len(cols)), s.field)
+	for _, r := range s.dense {
+		row := s.rows[r]
+		s.reduce(row)
+
+		vector := make(kodr_internals.CodingVector, uint(len(cols))*width)
+		for _, e := range row.entries {
+			s.field.SetSymbol(vector, column[e.col], e.coeff)
+		}
+		state.AddPiece(&kodr_internals.CodedPiece{Vector: vector, Piece: row.piece})
+		row.role, row.entries, row.piece = rowDropped, nil, nil
+	}
+	state.Rref()
+
+	s.dense = s.dense[:0]
+	resolved := false
+	coeffs, coded := state.CoefficientMatrix(), state.CodedPieceMatrix()
+	for i := range coeffs {
+		row := &sparseRow{piece: coded[i], role: rowDense}
+		for k, col := range cols {
+			if c := s.field.Symbol(coeffs[i], uint(k)); c != 0 {
+				row.entries = append(row.entries, sparseEntry{col: col, coeff: c})
+			}
+		}
+
+		r := len(s.rows)
+		s.rows = append(s.rows, row)
+		if len(row.entries) != 1 {
+			s.dense = append(s.dense, r)
+			continue
+		}
+
+		col := row.entries[0].col
+		s.inactive[col] = false
+		s.pivot(r, col)
+		resolved = true
+	}
+
+	if !resolved {
+		return
+	}
+	for _, r := range s.pivotRows {
+		s.reduce(s.rows[r])
+	}
+}
+
+// Finite field, coding coefficients & coded pieces are elements of
+func (s *SparseDecoderState) Field() field.Field {
+	return s.field
+}
+
+// Length of each coded piece, in bytes, 0 if none added yet
+func (s *SparseDecoderState) PieceLength() uint {
+	return s.pieceLength
+}
+
+// Adds a new coded piece to decoder state, keeping only nonzero
+// coefficients of coding vector
+//
+// Note: Coded piece is copied, caller's slices are never modified
+func (s *SparseDecoderState) AddPiece(codedPiece *kodr_internals.CodedPiece) {
+	row := &sparseRow{piece: make(kodr_internals.Piece, len(codedPiece.Piece)), role: rowPending}
+	copy(row.piece, codedPiece.Piece)
+	s.pieceLength = uint(len(codedPiece.Piece))
+
+	r := len(s.rows)
+	for col := range s.pieceCount {
+		c := s.field.Symbol(codedPiece.Vector, col)
+		if c == 0 {
+			continue
+		}
+
+		row.entries = append(row.entries, sparseEntry{col: col, coeff: c})
+		if s.free(col) {
+			row.degree++
+			s.adjacency[col] = append(s.adjacency[col], r)
+		}
+	}
+
+	s.rows = append(s.rows, row)
+	s.pending = append(s.pending, r)
+	s.pendingCount++
+	if row.degree <= 1 {
+		s.ripple = append(s.ripple, r)
+	}
+}
+
+// Pivots rows, as far as possible, then if enough rows are collected,
+// inactivates columns to make progress & solves inactivated ones densely
+func (s *SparseDecoderState) Rref() {
+	s.peel()
+	if s.pendingCount > 0 && s.pivots+s.pendingCount+uint(len(s.dense)) >= s.pieceCount {
+		s.inactivate()
+	}
+	s.solve()
+}
+
+// #-of linearly independent rows, as of last `Rref` call, not counting
+// rows still waiting for inactivation
+func (s *SparseDecoderState) Rank() uint {
+	return s.pivots + uint(len(s.dense))
+}
+
+// #-of columns inactivated so far i.e. solved densely, which is what
+// decoding cost mostly depends on
+func (s *SparseDecoderState) Inactivated() uint {
+	return s.inactivated
+}
+
+// Summary of subspace spanned by all received coding vectors, including
+// rows still waiting for inactivation, which are densified & RREF-ed
+func (s *SparseDecoderState) Summary() *Summary {
+	vectors := make([]kodr_internals.CodingVector, 0, len(s.rows))
+	for _, row := range s.rows {
+		if row.role == rowDropped {
+			continue
+		}
+
+		vector := make(kodr_internals.CodingVector, s.pieceCount*s.field.SymbolSize())
+		for _, e := range row.entries {
+			s.field.SetSymbol(vector, e.col, e.coeff)
+		}
+		vectors = append(vectors, vector)
+	}
+
+	return NewSummary(s.field, s.pieceCount, vectors)
+}
+
+// Request decoded piece by index ( 0 based ), which succeeds only if
+// pivot row of that column has no other nonzero coefficient
+//
+// Note: Piece is copied into newly allocated memory, unless whole
+// decoding has happened
+func (s *SparseDecoderState) GetPiece(idx uint) (kodr_internals.Piece, error) {
+	if idx >= s.pieceCount {
+		return nil, kodr.ErrPieceOutOfBound
+	}
+
+	p := s.pivotOf[idx]
+	if p < 0 || len(s.rows[p].entries) != 1 {
+		return nil, kodr.ErrPieceNotDecodedYet
+	}
+
+	if s.Rank() >= s.pieceCount {
+		return s.rows[p].piece, nil
+	}
+
+	buf := make(kodr_internals.Piece, len(s.rows[p].piece))
+	copy(buf, s.rows[p].piece)
+	return buf, nil
+}
+
+// Indices of original pieces, which are decodable right now i.e. whose
+// pivot row has no other nonzero coefficient, in increasing order
+func (s *SparseDecoderState) Decodable() []uint {
+	decodable := make([]uint, 0, s.pieceCount)
+	for i, p := range s.pivotOf {
+		if p >= 0 && len(s.rows[p].entries) == 1 {
+			decodable = append(decodable, uint(i))
+		}
+	}
+	return decodable
+}
+
+func NewSparseDecoderStateWithPieceCount(pieceCount uint) *SparseDecoderState {
+	return NewSparseDecoderStateWithField(pieceCount, field.Default())
+}
+
+// Sparse decoder state, where coding coefficients & coded pieces are
+// interpreted as sequence of elements of given finite field
+func NewSparseDecoderStateWithField(pieceCount uint, f field.Field) *SparseDecoderState {
+	pivotOf := make([]int, pieceCount)
+	for i := range pivotOf {
+		pivotOf[i] = -1
+	}
+
+	return &SparseDecoderState{
+		pieceCount: pieceCount,
+		field:      f,
+		pivotOf:    pivotOf,
+		inactive:   make([]bool, pieceCount),
+		adjacency:  make([][]int, pieceCount),
+		scratch:    make([]uint32, pieceCount),
+		marked:     make([]bool, pieceCount),
+	}
+}
+
+// Sparse decoder state, where coding coefficients are GF(2^16) elements
+func NewGf65536SparseDecoderStateWithPieceCount(pieceCount uint) *SparseDecoderState {
+	return NewSparseDecoderStateWithField(pieceCount, gf65536.DefaultField())
+}
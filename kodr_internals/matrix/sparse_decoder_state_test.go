@@ -0,0 +1,143 @@
+package matrix_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	math_rand "math/rand"
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf65536"
+	"github.com/itzmeanjan/kodr/kodr_internals/matrix"
+)
+
+// Coded piece, combining `degree` distinct original pieces, chosen
+// uniformly at random, with random nonzero coefficients
+func sparseCodedPiece(f field.Field, pieces [][]byte, degree int) *kodr_internals.CodedPiece {
+	width := f.SymbolSize()
+	vector := make(kodr_internals.CodingVector, uint(len(pieces))*width)
+	piece := make(kodr_internals.Piece, len(pieces[0]))
+
+	for _, i := range math_rand.Perm(len(pieces))[:degree] {
+		c := f.Random()
+		for c == 0 {
+			c = f.Random()
+		}
+
+		f.SetSymbol(vector, uint(i), c)
+		f.MulAddSlice(piece, pieces[i], c)
+	}
+
+	return &kodr_internals.CodedPiece{Vector: vector, Piece: piece}
+}
+
+func randomPieces(pieceCount, pieceLength int) [][]byte {
+	pieces := random_matrix(pieceCount, pieceLength, true)
+	for i := range pieces {
+		rand.Read(pieces[i])
+	}
+	return pieces
+}
+
+// Feeds sparse coded pieces to both sparse & dense decoder states, until
+// sparse one reaches full rank, while its rank must never exceed dense one's
+func sparseDecoderFlow(t *testing.T, f field.Field, pieceCount, degree int) *matrix.SparseDecoderState {
+	pieces := randomPieces(pieceCount, 64)
+	sparse := matrix.NewSparseDecoderStateWithField(uint(pieceCount), f)
+	dense := matrix.NewDecoderStateWithField(uint(pieceCount), f)
+
+	for sparse.Rank() < uint(pieceCount) {
+		c_piece := sparseCodedPiece(f, pieces, degree)
+		sparse.AddPiece(c_piece)
+		sparse.Rref()
+
+		// dense state works in-place, so it gets its own copy
+		vector := make(kodr_internals.CodingVector, len(c_piece.Vector))
+		copy(vector, c_piece.Vector)
+		piece := make(kodr_internals.Piece, len(c_piece.Piece))
+		copy(piece, c_piece.Piece)
+		dense.AddPiece(&kodr_internals.CodedPiece{Vector: vector, Piece: piece})
+		dense.Rref()
+
+		if sparse.Rank() > dense.Rank() {
+			t.Fatalf("sparse state rank %d > dense state rank %d\n", sparse.Rank(), dense.Rank())
+		}
+	}
+
+	for i := range pieces {
+		piece, err := sparse.GetPiece(uint(i))
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if !bytes.Equal(piece, pieces[i]) {
+			t.Fatal("decoded data doesn't match !")
+		}
+	}
+
+	return sparse
+}
+
+func TestSparseDecoderState(t *testing.T) {
+	for _, degree := range []int{1, 2, 3, 8} {
+		state := sparseDecoderFlow(t, field.Default(), 256, degree)
+		if state.Inactivated() >= 256 {
+			t.Fatalf("with degree %d, all columns got inactivated\n", degree)
+		}
+	}
+
+	// dense coding vectors too, inactivating almost every column
+	sparseDecoderFlow(t, field.Default(), 64, 64)
+	sparseDecoderFlow(t, gf65536.DefaultField(), 128, 4)
+}
+
+func TestSparseDecoderStatePartialDecoding(t *testing.T) {
+	var (
+		f      = field.Default()
+		pieces = randomPieces(8, 32)
+		state  = matrix.NewSparseDecoderStateWithPieceCount(8)
+	)
+
+	add := func(coeffs map[int]uint32) {
+		vector := make(kodr_internals.CodingVector, 8)
+		piece := make(kodr_internals.Piece, 32)
+		for i, c := range coeffs {
+			f.SetSymbol(vector, uint(i), c)
+			f.MulAddSlice(piece, pieces[i], c)
+		}
+
+		state.AddPiece(&kodr_internals.CodedPiece{Vector: vector, Piece: piece})
+		state.Rref()
+	}
+
+	add(map[int]uint32{3: 7})
+	add(map[int]uint32{3: 1, 5: 9})
+	add(map[int]uint32{3: 2, 5: 9})
+	add(map[int]uint32{0: 1, 1: 1})
+
+	for _, i := range []uint{3, 5} {
+		piece, err := state.GetPiece(i)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if !bytes.Equal(piece, pieces[i]) {
+			t.Fatal("decoded data doesn't match !")
+		}
+	}
+
+	if _, err := state.GetPiece(0); !errors.Is(err, kodr.ErrPieceNotDecodedYet) {
+		t.Fatalf("expected: %s\n", kodr.ErrPieceNotDecodedYet)
+	}
+	if _, err := state.GetPiece(8); !errors.Is(err, kodr.ErrPieceOutOfBound) {
+		t.Fatalf("expected: %s\n", kodr.ErrPieceOutOfBound)
+	}
+
+	// linearly dependent one mustn't raise rank
+	rank := state.Rank()
+	add(map[int]uint32{3: 3, 5: 4})
+	if state.Rank() != rank {
+		t.Fatalf("expected rank %d, found %d\n", rank, state.Rank())
+	}
+}
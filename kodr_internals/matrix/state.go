@@ -0,0 +1,29 @@
+package matrix
+
+import (
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+)
+
+// State - Decoder side linear system, collecting coded pieces & reducing
+// them, so that original pieces are revealed. `DecoderState` is the dense
+// implementation, while `SparseDecoderState` suits sparse coding vectors,
+// which decoders can choose from
+type State interface {
+	// Finite field, coding coefficients & coded pieces are elements of
+	Field() field.Field
+	// Length of each coded piece, in bytes, 0 if none added yet
+	PieceLength() uint
+	// Adds a new coded piece, which is reduced on next `Rref` call
+	AddPiece(codedPiece *kodr_internals.CodedPiece)
+	// Reduces collected coded pieces, dropping linearly dependent ones
+	Rref()
+	// #-of linearly independent coded pieces, as of last `Rref` call
+	Rank() uint
+	// Indices of original pieces, which are decodable right now
+	Decodable() []uint
+	// Decoded original piece at index, if revealed
+	GetPiece(idx uint) (kodr_internals.Piece, error)
+	// Summary of subspace spanned by received coding vectors
+	Summary() *Summary
+}
@@ -0,0 +1,228 @@
+package matrix
+
+import (
+	"encoding/binary"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+)
+
+// Summary - Compact description of subspace, spanned by coding vectors
+// a decoder has received, being RREF basis of it, along with pivot column
+// of each basis vector. It carries no coded piece, so that it can be sent
+// back to sender, which then crafts coded pieces, guaranteed to be
+// innovative for that decoder
+type Summary struct {
+	pieceCount uint
+	field      field.Field
+	pivots     []uint
+	basis      Matrix
+}
+
+// #-of pieces coded together i.e. #-of coefficients in each coding vector
+func (s *Summary) PieceCount() uint {
+	return s.pieceCount
+}
+
+// Finite field, coding coefficients are elements of
+func (s *Summary) Field() field.Field {
+	return s.field
+}
+
+// Dimension of summarized subspace
+func (s *Summary) Rank() uint {
+	return uint(len(s.pivots))
+}
+
+// Pivot column of each basis vector, in increasing order
+func (s *Summary) Pivots() []uint {
+	return s.pivots
+}
+
+// RREF basis of summarized subspace, one coding vector per row
+func (s *Summary) Basis() Matrix {
+	return s.basis
+}
+
+// Columns without pivot i.e. original pieces, which can't yet be
+// decoded, even if they're part of some basis vector
+func (s *Summary) Free() []uint {
+	free := make([]uint, 0, s.pieceCount-s.Rank())
+	k := 0
+	for col := range s.pieceCount {
+		if k < len(s.pivots) && s.pivots[k] == col {
+			k++
+			continue
+		}
+		free = append(free, col)
+	}
+	return free
+}
+
+// Residual of coding vector, after eliminating its component along each
+// basis vector, which is zero iff given vector lies in summarized subspace
+//
+// Note: Coding vector is copied, caller's slice is never modified
+func (s *Summary) Residual(vector kodr_internals.CodingVector) kodr_internals.CodingVector {
+	residual := make(kodr_internals.CodingVector, len(vector))
+	copy(residual, vector)
+
+	for k, pivot := range s.pivots {
+		c := s.field.Symbol(residual, pivot)
+		if c == 0 {
+			continue
+		}
+		s.field.MulAddSlice(residual, s.basis[k], s.field.Sub(0, c))
+	}
+	return residual
+}
+
+// Whether coding vector would raise rank of summarized decoder
+func (s *Summary) IsInnovative(vector kodr_internals.CodingVector) bool {
+	for _, b := range s.Residual(vector) {
+		if b != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Summary of subspace spanned by union of both summarized subspaces
+// i.e. what decoder would have, after receiving everything other one has
+func (s *Summary) Union(other *Summary) (*Summary, error) {
+	if s.pieceCount != other.pieceCount || s.field.ID() != other.field.ID() {
+		return nil, kodr.ErrSummaryMismatch
+	}
+
+	vectors := make([]kodr_internals.CodingVector, 0, s.Rank()+other.Rank())
+	for _, row := range s.basis {
+		vectors = append(vectors, row)
+	}
+	for _, row := range other.basis {
+		vectors = append(vectors, row)
+	}
+	return NewSummary(s.field, s.pieceCount, vectors), nil
+}
+
+// Dimension of union of both summarized subspaces
+func (s *Summary) UnionRank(other *Summary) (uint, error) {
+	union, err := s.Union(other)
+	if err != nil {
+		return 0, err
+	}
+	return union.Rank(), nil
+}
+
+// #-of linearly independent pieces, other peer could give, which are
+// innovative for this one i.e. "do you have something I don't ?"
+func (s *Summary) InnovativeFrom(other *Summary) (uint, error) {
+	rank, err := s.UnionRank(other)
+	if err != nil {
+		return 0, err
+	}
+	return rank - s.Rank(), nil
+}
+
+// Flattens summary into compact byte slice, laid out as
+//
+// uvarint(pieceCount) || field id ( 4 bytes, big endian ) || pivot bitmap ( ⌈N/8⌉ bytes ) || basis
+//
+// where only coefficients at non-pivot columns of each basis vector are
+// kept, because RREF basis has 1 at its own pivot & 0 at other pivots.
+// So it takes r * ( N - r ) symbols, for subspace of rank r
+func (s *Summary) Flatten() []byte {
+	width := s.field.SymbolSize()
+	free := s.Free()
+	bitmap := (s.pieceCount + 7) / 8
+
+	res := make([]byte, 0, binary.MaxVarintLen64+4+bitmap+s.Rank()*uint(len(free))*width)
+	res = binary.AppendUvarint(res, uint64(s.pieceCount))
+	res = binary.BigEndian.AppendUint32(res, s.field.ID())
+
+	pivots := make([]byte, bitmap)
+	for _, pivot := range s.pivots {
+		pivots[pivot/8] |= 1 << (pivot % 8)
+	}
+	res = append(res, pivots...)
+
+	for _, row := range s.basis {
+		for _, col := range free {
+			res = append(res, row[col*width:(col+1)*width]...)
+		}
+	}
+	return res
+}
+
+// Reconstructs summary from flattened form, which must have been
+// computed over given finite field
+func SummaryFromFlattened(data []byte, f field.Field) (*Summary, error) {
+	pieceCount, n := binary.Uvarint(data)
+	if n <= 0 || len(data[n:]) < 4 {
+		return nil, kodr.ErrMalformedSummary
+	}
+	data = data[n:]
+
+	if binary.BigEndian.Uint32(data) != f.ID() {
+		return nil, kodr.ErrFieldMismatch
+	}
+	data = data[4:]
+
+	// bitmap needs one bit per piece, checked before computing its
+	// length, which would otherwise overflow for huge piece count
+	if pieceCount > uint64(len(data))*8 {
+		return nil, kodr.ErrMalformedSummary
+	}
+	bitmap := (pieceCount + 7) / 8
+	if tail := pieceCount % 8; tail != 0 && data[bitmap-1]>>tail != 0 {
+		return nil, kodr.ErrMalformedSummary
+	}
+
+	summary := &Summary{pieceCount: uint(pieceCount), field: f}
+	for col := range summary.pieceCount {
+		if data[col/8]&(1<<(col%8)) != 0 {
+			summary.pivots = append(summary.pivots, col)
+		}
+	}
+	data = data[bitmap:]
+
+	width := f.SymbolSize()
+	free := summary.Free()
+	// compared using division, as expected length may overflow
+	if rows := summary.Rank() * width; rows == 0 || len(free) == 0 {
+		if len(data) != 0 {
+			return nil, kodr.ErrMalformedSummary
+		}
+	} else if uint(len(data))%rows != 0 || uint(len(data))/rows != uint(len(free)) {
+		return nil, kodr.ErrMalformedSummary
+	}
+
+	summary.basis = make(Matrix, 0, len(summary.pivots))
+	for _, pivot := range summary.pivots {
+		row := make([]byte, summary.pieceCount*width)
+		f.SetSymbol(row, pivot, 1)
+		for _, col := range free {
+			copy(row[col*width:], data[:width])
+			data = data[width:]
+		}
+		summary.basis = append(summary.basis, row)
+	}
+
+	return summary, nil
+}
+
+// Computes summary of subspace spanned by given coding vectors, which
+// are copied first, so that those are never modified
+func NewSummary(f field.Field, pieceCount uint, vectors []kodr_internals.CodingVector) *Summary {
+	state := NewDecoderStateWithField(pieceCount, f)
+	for _, vector := range vectors {
+		v := make(kodr_internals.CodingVector, len(vector))
+		copy(v, vector)
+		state.AddPiece(&kodr_internals.CodedPiece{Vector: v, Piece: kodr_internals.Piece{}})
+	}
+
+	if len(vectors) > 0 {
+		state.Rref()
+	}
+	return state.Summary()
+}
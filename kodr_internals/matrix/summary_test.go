@@ -0,0 +1,193 @@
+package matrix_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf65536"
+	"github.com/itzmeanjan/kodr/kodr_internals/matrix"
+)
+
+func TestSummary(t *testing.T) {
+	f := field.Default()
+	vectors := []kodr_internals.CodingVector{{0, 3, 0, 5, 0}, {0, 6, 0, 10, 0}, {0, 0, 0, 0, 7}}
+	summary := matrix.NewSummary(f, 5, vectors)
+
+	if summary.Rank() != 2 {
+		t.Fatalf("expected rank 2, found %d\n", summary.Rank())
+	}
+	if !slices.Equal(summary.Pivots(), []uint{1, 4}) {
+		t.Fatalf("expected pivots [1 4], found %v\n", summary.Pivots())
+	}
+	if !slices.Equal(summary.Free(), []uint{0, 2, 3}) {
+		t.Fatalf("expected free columns [0 2 3], found %v\n", summary.Free())
+	}
+
+	// given vectors must be left untouched
+	if !slices.Equal(vectors[0], kodr_internals.CodingVector{0, 3, 0, 5, 0}) {
+		t.Fatal("coding vector got modified")
+	}
+
+	for _, vector := range vectors {
+		if summary.IsInnovative(vector) {
+			t.Fatalf("%v lies in summarized subspace\n", vector)
+		}
+	}
+
+	combined := kodr_internals.CodingVector{0, 3, 0, 5, 1}
+	if summary.IsInnovative(combined) {
+		t.Fatalf("%v lies in summarized subspace\n", combined)
+	}
+	if innovative := (kodr_internals.CodingVector{0, 3, 0, 4, 0}); !summary.IsInnovative(innovative) {
+		t.Fatalf("%v doesn't lie in summarized subspace\n", innovative)
+	}
+}
+
+// Sparse decoder state must summarize same subspace as dense one,
+// even when some rows are still waiting for inactivation
+func TestSparseDecoderStateSummary(t *testing.T) {
+	var (
+		f          = field.Default()
+		pieceCount = 64
+		pieces     = randomPieces(pieceCount, 16)
+		sparse     = matrix.NewSparseDecoderStateWithPieceCount(uint(pieceCount))
+		dense      = matrix.NewDecoderStateWithPieceCount(uint(pieceCount))
+	)
+
+	for range 40 {
+		c_piece := sparseCodedPiece(f, pieces, 3)
+		sparse.AddPiece(c_piece)
+		sparse.Rref()
+
+		dense.AddPiece(&kodr_internals.CodedPiece{
+			Vector: append(kodr_internals.CodingVector(nil), c_piece.Vector...),
+			Piece:  append(kodr_internals.Piece(nil), c_piece.Piece...),
+		})
+		dense.Rref()
+	}
+
+	s_summary, d_summary := sparse.Summary(), dense.Summary()
+	if !slices.Equal(s_summary.Pivots(), d_summary.Pivots()) {
+		t.Fatalf("pivots %v != %v\n", s_summary.Pivots(), d_summary.Pivots())
+	}
+	if basis := s_summary.Basis(); !basis.Cmp(d_summary.Basis()) {
+		t.Fatal("basis doesn't match !")
+	}
+}
+
+func TestSummaryFlatten(t *testing.T) {
+	for _, f := range []field.Field{field.Default(), gf65536.DefaultField()} {
+		pieces := randomPieces(20, 8)
+		vectors := make([]kodr_internals.CodingVector, 0, 12)
+		for range 12 {
+			vectors = append(vectors, sparseCodedPiece(f, pieces, 4).Vector)
+		}
+		summary := matrix.NewSummary(f, 20, vectors)
+
+		flattened := summary.Flatten()
+		// uvarint(20) + field id + 3 bytes of bitmap + r * ( N - r ) symbols
+		if expected := 1 + 4 + 3 + int(summary.Rank()*(20-summary.Rank())*f.SymbolSize()); len(flattened) != expected {
+			t.Fatalf("expected flattened summary of %dB, found %dB\n", expected, len(flattened))
+		}
+
+		restored, err := matrix.SummaryFromFlattened(flattened, f)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if !slices.Equal(restored.Pivots(), summary.Pivots()) {
+			t.Fatalf("pivots %v != %v\n", restored.Pivots(), summary.Pivots())
+		}
+		if basis := restored.Basis(); !basis.Cmp(summary.Basis()) {
+			t.Fatal("basis doesn't match !")
+		}
+
+		if _, err := matrix.SummaryFromFlattened(flattened[:len(flattened)-1], f); !errors.Is(err, kodr.ErrMalformedSummary) {
+			t.Fatalf("expected: %s\n", kodr.ErrMalformedSummary)
+		}
+	}
+
+	flattened := matrix.NewSummary(field.Default(), 4, nil).Flatten()
+	if _, err := matrix.SummaryFromFlattened(flattened, gf65536.DefaultField()); !errors.Is(err, kodr.ErrFieldMismatch) {
+		t.Fatalf("expected: %s\n", kodr.ErrFieldMismatch)
+	}
+}
+
+func TestSummaryFromMalformed(t *testing.T) {
+	f := field.Default()
+	header := func(pieceCount uint64) []byte {
+		buf := binary.AppendUvarint(nil, pieceCount)
+		return binary.BigEndian.AppendUint32(buf, f.ID())
+	}
+
+	malformed := [][]byte{
+		// piece count, whose bitmap length overflows
+		append(header(math.MaxUint64), 0xff, 0xff),
+		// bitmap shorter than piece count requires
+		append(header(20), 0xff, 0xff),
+		// pivot at 5th column, though only 4 pieces are coded together
+		append(header(4), 0b10001),
+		// full rank, yet some basis coefficients follow
+		append(header(4), 0b1111, 0),
+		// truncated piece count
+		{0x80},
+	}
+
+	for i, data := range malformed {
+		if _, err := matrix.SummaryFromFlattened(data, f); !errors.Is(err, kodr.ErrMalformedSummary) {
+			t.Fatalf("case %d: expected: %s, received: %v\n", i, kodr.ErrMalformedSummary, err)
+		}
+	}
+
+	summary, err := matrix.SummaryFromFlattened(append(header(4), 0b1111), f)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if summary.Rank() != 4 {
+		t.Fatalf("expected rank 4, found %d\n", summary.Rank())
+	}
+}
+
+func TestSummaryUnion(t *testing.T) {
+	var (
+		f      = field.Default()
+		pieces = randomPieces(32, 8)
+		shared = make([]kodr_internals.CodingVector, 0, 10)
+		mine   = make([]kodr_internals.CodingVector, 0, 15)
+		theirs = make([]kodr_internals.CodingVector, 0, 15)
+	)
+
+	// both peers have 10 pieces in common, besides 5 pieces each, which
+	// only they have, all of them being linearly independent
+	for range 10 {
+		shared = append(shared, sparseCodedPiece(f, pieces, 32).Vector)
+	}
+	mine = append(mine, shared...)
+	theirs = append(theirs, shared...)
+	for range 5 {
+		mine = append(mine, sparseCodedPiece(f, pieces, 32).Vector)
+		theirs = append(theirs, sparseCodedPiece(f, pieces, 32).Vector)
+	}
+
+	m_summary, t_summary := matrix.NewSummary(f, 32, mine), matrix.NewSummary(f, 32, theirs)
+	if rank, err := m_summary.UnionRank(t_summary); err != nil || rank != 20 {
+		t.Fatalf("expected union rank 20, found %d ( %v )\n", rank, err)
+	}
+	if count, err := m_summary.InnovativeFrom(t_summary); err != nil || count != 5 {
+		t.Fatalf("expected 5 innovative pieces, found %d ( %v )\n", count, err)
+	}
+
+	s_summary := matrix.NewSummary(f, 32, shared)
+	if count, _ := m_summary.InnovativeFrom(s_summary); count != 0 {
+		t.Fatalf("expected no innovative piece, found %d\n", count)
+	}
+
+	if _, err := m_summary.UnionRank(matrix.NewSummary(f, 31, nil)); !errors.Is(err, kodr.ErrSummaryMismatch) {
+		t.Fatalf("expected: %s\n", kodr.ErrSummaryMismatch)
+	}
+}
@@ -0,0 +1,66 @@
+// Perpetual ( band ) codes, where non-zero coding coefficients of each
+// coded piece fall within a contiguous band of width w, starting at some
+// offset & wrapping around at end, so that coded piece only needs to carry
+// ( offset, w coefficients ) & decoder needs O(N * w) operations, instead
+// of O(N^2), while decodability stays close to full RLNC
+package perpetual
+
+import (
+	"encoding/binary"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+)
+
+// Coded piece, where `Vector[i]` is coding coefficient of original
+// piece at index ( Offset + i ) % N
+type CodedPiece struct {
+	Offset uint
+	Vector kodr_internals.CodingVector
+	Piece  kodr_internals.Piece
+}
+
+// Total length of coded piece, when flattened --- len(uvarint(offset))
+// + len(coding_vector) + len(piece)
+func (c *CodedPiece) Len() uint {
+	var buf [binary.MaxVarintLen64]byte
+	return uint(binary.PutUvarint(buf[:], uint64(c.Offset))+len(c.Vector)) + uint(len(c.Piece))
+}
+
+// Flattens coded piece into single byte slice
+// ( uvarint(offset) ++ vector ++ piece )
+func (c *CodedPiece) Flatten() []byte {
+	res := make([]byte, 0, c.Len())
+	res = binary.AppendUvarint(res, uint64(c.Offset))
+	res = append(res, c.Vector...)
+	res = append(res, c.Piece...)
+	return res
+}
+
+// Expands band coding vector to full coding vector over N pieces,
+// so that coded piece can also be consumed by full RLNC decoder
+func (c *CodedPiece) Dense(pieceCount uint) *kodr_internals.CodedPiece {
+	vector := make(kodr_internals.CodingVector, pieceCount)
+	for i, v := range c.Vector {
+		vector[(c.Offset+uint(i))%pieceCount] ^= v
+	}
+
+	piece := make(kodr_internals.Piece, len(c.Piece))
+	copy(piece, c.Piece)
+	return &kodr_internals.CodedPiece{Vector: vector, Piece: piece}
+}
+
+// Splits flattened coded piece back into its components, given
+// band width, both encoder & decoder agree upon
+func CodedPieceFromFlattened(data []byte, width uint) (*CodedPiece, error) {
+	offset, n := binary.Uvarint(data)
+	if n <= 0 || !(uint(n)+width < uint(len(data))) {
+		return nil, kodr.ErrCodingVectorLengthMismatch
+	}
+
+	return &CodedPiece{
+		Offset: uint(offset),
+		Vector: data[n : uint(n)+width],
+		Piece:  data[uint(n)+width:],
+	}, nil
+}
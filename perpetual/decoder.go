@@ -0,0 +1,237 @@
+package perpetual
+
+import (
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+	"github.com/itzmeanjan/kodr/kodr_internals/matrix"
+)
+
+// Row of decoder's system, where coefficients of active columns
+// i.e. [w-1, N) are kept as a band of width w, starting at `start`,
+// while coefficients of inactive columns i.e. [0, w-1), where wrapped
+// around bands land, are kept densely
+type row struct {
+	start uint
+	band  []byte
+	tail  []byte
+	piece []byte
+}
+
+// Decoder exploiting band structure of coding vectors. Each received piece
+// is eliminated using already stored rows, whose pivots fall within its
+// band, while band never grows wider than w, so that it costs O(w) row
+// operations. Pieces which are eliminated on all active columns only
+// involve w-1 inactive columns, which are solved densely
+//
+// Once rank reaches N, inactive columns are solved first, then active
+// ones, by back substitution, costing O(N * w) row operations in total
+type PerpetualDecoder struct {
+	pieceCount, width, inactive uint
+	received, pieceLength       uint
+	pivots                      []*row
+	active                      uint
+	tails                       *matrix.DecoderState
+	decoded                     []kodr_internals.Piece
+}
+
+// PieceLength - Returns piece length in bytes
+//
+// If no pieces are yet added to decoder, then
+// returns 0, denoting **unknown**
+func (d *PerpetualDecoder) PieceLength() uint {
+	return d.pieceLength
+}
+
+// IsDecoded - Use it for checking whether more piece
+// collection is required or not
+func (d *PerpetualDecoder) IsDecoded() bool {
+	return d.decoded != nil
+}
+
+// Rank - How many linearly independent pieces are received so far
+func (d *PerpetualDecoder) Rank() uint {
+	return d.active + d.tails.Rank()
+}
+
+// Required - How many more linearly independent pieces
+// are required for successfully decoding pieces ?
+func (d *PerpetualDecoder) Required() uint {
+	return d.pieceCount - d.Rank()
+}
+
+// Received - How many coded pieces are received so far, including
+// linearly dependent ones
+func (d *PerpetualDecoder) Received() uint {
+	return d.received
+}
+
+// Splits band of coded piece into active band & inactive tail
+func (d *PerpetualDecoder) newRow(piece *CodedPiece) *row {
+	r := &row{
+		start: d.pieceCount,
+		band:  make([]byte, d.width),
+		tail:  make([]byte, d.inactive),
+		piece: make([]byte, len(piece.Piece)),
+	}
+	copy(r.piece, piece.Piece)
+
+	for i, v := range piece.Vector {
+		col := (piece.Offset + uint(i)) % d.pieceCount
+		if col < d.inactive {
+			r.tail[col] ^= v
+			continue
+		}
+		r.start = min(r.start, col)
+	}
+	for i, v := range piece.Vector {
+		if col := (piece.Offset + uint(i)) % d.pieceCount; col >= d.inactive {
+			r.band[col-r.start] ^= v
+		}
+	}
+
+	return r
+}
+
+// AddPiece - Adds a new received coded piece, which is eliminated right
+// away, keeping it only if it's linearly independent of already stored rows
+func (d *PerpetualDecoder) AddPiece(piece *CodedPiece) error {
+	if d.IsDecoded() {
+		return kodr.ErrAllUsefulPiecesReceived
+	}
+	if uint(len(piece.Vector)) != d.width {
+		return kodr.ErrBadBandWidth
+	}
+	if piece.Offset >= d.pieceCount {
+		return kodr.ErrPieceOutOfBound
+	}
+	if d.received > 0 && uint(len(piece.Piece)) != d.pieceLength {
+		return kodr.ErrCodedDataLengthMismatch
+	}
+
+	d.received++
+	d.pieceLength = uint(len(piece.Piece))
+	f := field.Default()
+	r := d.newRow(piece)
+
+	pivoted := false
+	for {
+		lead := -1
+		for i, v := range r.band {
+			if v != 0 {
+				lead = i
+				break
+			}
+		}
+		if lead < 0 {
+			break
+		}
+
+		// rebasing band at leading column, which keeps it within
+		// [lead, lead + w), as stored row's band is
+		copy(r.band, r.band[lead:])
+		clear(r.band[d.width-uint(lead):])
+		r.start += uint(lead)
+
+		c := uint32(r.band[0])
+		stored := d.pivots[r.start-d.inactive]
+		if stored == nil {
+			inv, _ := f.Inv(c)
+			f.MulSlice(r.band, inv)
+			f.MulSlice(r.tail, inv)
+			f.MulSlice(r.piece, inv)
+
+			d.pivots[r.start-d.inactive] = r
+			d.active++
+			pivoted = true
+			break
+		}
+
+		// row -= c * stored, which zeroes leading column
+		f.MulAddSlice(r.band, stored.band, c)
+		f.MulAddSlice(r.tail, stored.tail, c)
+		f.MulAddSlice(r.piece, stored.piece, c)
+	}
+
+	// only inactive columns are left, which are solved densely
+	if !pivoted && d.inactive > 0 {
+		d.tails.AddPiece(&kodr_internals.CodedPiece{Vector: r.tail, Piece: r.piece})
+		d.tails.Rref()
+	}
+
+	d.decode()
+	return nil
+}
+
+// Solves inactive columns first, then active ones, from last to first,
+// by back substitution, given rank has reached N
+func (d *PerpetualDecoder) decode() {
+	if d.Rank() < d.pieceCount {
+		return
+	}
+
+	f := field.Default()
+	decoded := make([]kodr_internals.Piece, d.pieceCount)
+	for i := range d.inactive {
+		// rank is full, so all inactive columns are solved
+		decoded[i], _ = d.tails.GetPiece(i)
+	}
+
+	for p := d.pieceCount - 1; p >= d.inactive; p-- {
+		r := d.pivots[p-d.inactive]
+
+		piece := make(kodr_internals.Piece, len(r.piece))
+		copy(piece, r.piece)
+		for k := uint(1); k < d.width && p+k < d.pieceCount; k++ {
+			f.MulAddSlice(piece, decoded[p+k], uint32(r.band[k]))
+		}
+		for i := range d.inactive {
+			f.MulAddSlice(piece, decoded[i], uint32(r.tail[i]))
+		}
+
+		decoded[p] = piece
+		if p == 0 {
+			break
+		}
+	}
+
+	d.decoded = decoded
+}
+
+// GetPiece - Get a decoded piece by index, given full
+// decoding has happened
+func (d *PerpetualDecoder) GetPiece(i uint) (kodr_internals.Piece, error) {
+	if i >= d.pieceCount {
+		return nil, kodr.ErrPieceOutOfBound
+	}
+	if !d.IsDecoded() {
+		return nil, kodr.ErrPieceNotDecodedYet
+	}
+	return d.decoded[i], nil
+}
+
+// GetPieces - Get a list of all decoded pieces, given full
+// decoding has happened
+func (d *PerpetualDecoder) GetPieces() ([]kodr_internals.Piece, error) {
+	if !d.IsDecoded() {
+		return nil, kodr.ErrMoreUsefulPiecesRequired
+	}
+	return d.decoded, nil
+}
+
+// Decoder for pieces coded by perpetual encoder, with same
+// #-of pieces & band width
+func NewPerpetualDecoder(pieceCount, width uint) (*PerpetualDecoder, error) {
+	if width == 0 || width > pieceCount {
+		return nil, kodr.ErrBadBandWidth
+	}
+
+	inactive := width - 1
+	return &PerpetualDecoder{
+		pieceCount: pieceCount,
+		width:      width,
+		inactive:   inactive,
+		pivots:     make([]*row, pieceCount-inactive),
+		tails:      matrix.NewDecoderStateWithPieceCount(inactive),
+	}, nil
+}
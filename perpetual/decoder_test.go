@@ -0,0 +1,183 @@
+package perpetual_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/full"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/perpetual"
+)
+
+// Generates N-many pieces each of M-bytes length, to be used
+// for testing purposes
+func generatePieces(pieceCount uint, pieceLength uint) []kodr_internals.Piece {
+	pieces := make([]kodr_internals.Piece, 0, pieceCount)
+	for range pieceCount {
+		piece := make(kodr_internals.Piece, pieceLength)
+		rand.Read(piece)
+		pieces = append(pieces, piece)
+	}
+	return pieces
+}
+
+// Keeps feeding coded pieces to decoder until it's able to decode,
+// then compares decoded pieces with original ones & returns #-of
+// coded pieces it took
+func decoderFlow(t *testing.T, enc *perpetual.PerpetualEncoder, pieces []kodr_internals.Piece) uint {
+	dec, err := perpetual.NewPerpetualDecoder(enc.PieceCount(), enc.Width())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	for {
+		if err := dec.AddPiece(enc.CodedPiece()); errors.Is(err, kodr.ErrAllUsefulPiecesReceived) {
+			break
+		} else if err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	d_pieces, err := dec.GetPieces()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	for i := range pieces {
+		if !bytes.Equal(pieces[i], d_pieces[i]) {
+			t.Fatal("decoded data doesn't match !")
+		}
+	}
+
+	return dec.Received()
+}
+
+func TestPerpetualDecoder(t *testing.T) {
+	var (
+		pieceCount  uint = 128
+		pieceLength uint = 256
+	)
+
+	for _, width := range []uint{1, 2, 8, 16, 65, pieceCount} {
+		pieces := generatePieces(pieceCount, pieceLength)
+
+		enc, err := perpetual.NewPerpetualEncoder(pieces, width, perpetual.RandomOffset)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		decoderFlow(t, enc, pieces)
+
+		enc, err = perpetual.NewPerpetualEncoder(pieces, width, perpetual.CyclicOffset)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		decoderFlow(t, enc, pieces)
+	}
+}
+
+func TestPerpetualDecoderPieceLengthMismatch(t *testing.T) {
+	var (
+		pieceCount  uint = 32
+		pieceLength uint = 64
+		width       uint = 8
+	)
+
+	enc, err := perpetual.NewPerpetualEncoder(generatePieces(pieceCount, pieceLength), width, perpetual.CyclicOffset)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	dec, err := perpetual.NewPerpetualDecoder(pieceCount, width)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if err := dec.AddPiece(enc.CodedPiece()); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	piece := enc.CodedPiece()
+	piece.Piece = piece.Piece[:pieceLength-1]
+	if err := dec.AddPiece(piece); !errors.Is(err, kodr.ErrCodedDataLengthMismatch) {
+		t.Fatalf("expected: %s\n", kodr.ErrCodedDataLengthMismatch)
+	}
+	if dec.Received() != 1 || dec.PieceLength() != pieceLength {
+		t.Fatalf("expected 1 piece of %d bytes received, found %d of %d bytes\n", pieceLength, dec.Received(), dec.PieceLength())
+	}
+}
+
+// With wide enough band, decodability must stay close to full RLNC
+// over GF(2^8), where hardly any extra piece is required
+func TestPerpetualDecodingOverhead(t *testing.T) {
+	var (
+		pieceCount  uint = 128
+		pieceLength uint = 32
+		width       uint = 24
+		rounds      uint = 16
+		received    uint = 0
+	)
+
+	for range rounds {
+		pieces := generatePieces(pieceCount, pieceLength)
+		enc, err := perpetual.NewPerpetualEncoder(pieces, width, perpetual.RandomOffset)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		received += decoderFlow(t, enc, pieces)
+	}
+
+	if avg := float64(received-rounds*pieceCount) / float64(rounds); avg > 1 {
+		t.Fatalf("expected < 1 extra piece on average, found %.2f\n", avg)
+	}
+}
+
+func TestPerpetualCodedPiece(t *testing.T) {
+	var (
+		pieceCount  uint = 32
+		pieceLength uint = 64
+		width       uint = 24
+		pieces           = generatePieces(pieceCount, pieceLength)
+	)
+
+	if _, err := perpetual.NewPerpetualEncoder(pieces, pieceCount+1, perpetual.RandomOffset); !errors.Is(err, kodr.ErrBadBandWidth) {
+		t.Fatalf("expected: %s\n", kodr.ErrBadBandWidth)
+	}
+
+	enc, err := perpetual.NewPerpetualEncoder(pieces, width, perpetual.CyclicOffset)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// band pieces can be consumed by full RLNC decoder too
+	dec := full.NewFullRLNCDecoder(pieceCount)
+	for !dec.IsDecoded() {
+		c_piece := enc.CodedPiece()
+		if c_piece.Len() != 1+width+pieceLength {
+			t.Fatalf("expected flattened coded piece of %dB, found %dB\n", 1+width+pieceLength, c_piece.Len())
+		}
+
+		parsed, err := perpetual.CodedPieceFromFlattened(c_piece.Flatten(), width)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if parsed.Offset != c_piece.Offset || !bytes.Equal(parsed.Vector, c_piece.Vector) || !bytes.Equal(parsed.Piece, c_piece.Piece) {
+			t.Fatal("flattened coded piece doesn't parse back to itself")
+		}
+
+		if err := dec.AddPiece(parsed.Dense(pieceCount)); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	d_pieces, err := dec.GetPieces()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	for i := range pieces {
+		if !bytes.Equal(pieces[i], d_pieces[i]) {
+			t.Fatal("decoded data doesn't match !")
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package perpetual
+
+import (
+	"math/rand"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+)
+
+// How offset of band is chosen for each coded piece
+type OffsetMode uint8
+
+const (
+	// Offset is drawn uniformly at random
+	RandomOffset OffsetMode = iota
+	// Offset keeps moving by 1, wrapping around at end
+	CyclicOffset
+)
+
+type PerpetualEncoder struct {
+	pieces []kodr_internals.Piece
+	width  uint
+	mode   OffsetMode
+	next   uint
+	extra  uint
+}
+
+// Total #-of pieces being coded together
+func (p *PerpetualEncoder) PieceCount() uint {
+	return uint(len(p.pieces))
+}
+
+// Pieces which are coded together are all of same size
+func (p *PerpetualEncoder) PieceSize() uint {
+	return uint(len(p.pieces[0]))
+}
+
+// Width of band i.e. #-of coding coefficients each coded piece carries
+func (p *PerpetualEncoder) Width() uint {
+	return p.width
+}
+
+// How many extra padding bytes added at end of
+// original data slice so that splitted pieces are
+// all of same size ?
+func (p *PerpetualEncoder) Padding() uint {
+	return p.extra
+}
+
+// Returns a coded piece, combining w consecutive original pieces ( wrapping
+// around at end ), starting at offset. As done in perpetual codes, first
+// coefficient of band is always 1, while rest are randomly drawn
+func (p *PerpetualEncoder) CodedPiece() *CodedPiece {
+	var offset uint
+	switch p.mode {
+	case CyclicOffset:
+		offset = p.next
+		p.next = (p.next + 1) % p.PieceCount()
+
+	default:
+		offset = uint(rand.Intn(int(p.PieceCount())))
+
+	}
+
+	f := field.Default()
+	vector := f.RandomVector(p.width)
+	vector[0] = 1
+
+	piece := make(kodr_internals.Piece, p.PieceSize())
+	for i := range p.width {
+		f.MulAddSlice(piece, p.pieces[(offset+i)%p.PieceCount()], f.Symbol(vector, i))
+	}
+
+	return &CodedPiece{Offset: offset, Vector: vector, Piece: piece}
+}
+
+// Provide with original pieces, band width ( in [1, N] ) & how
+// band offsets are to be chosen, to get perpetual encoder
+func NewPerpetualEncoder(pieces []kodr_internals.Piece, width uint, mode OffsetMode) (*PerpetualEncoder, error) {
+	if width == 0 || width > uint(len(pieces)) {
+		return nil, kodr.ErrBadBandWidth
+	}
+
+	return &PerpetualEncoder{pieces: pieces, width: width, mode: mode}, nil
+}
+
+// If you know #-of pieces you want to code together, invoking
+// this function splits whole data chunk into N-pieces, with padding
+// bytes appended at end of last piece, if required & prepares
+// perpetual encoder
+func NewPerpetualEncoderWithPieceCount(data []byte, pieceCount uint, width uint, mode OffsetMode) (*PerpetualEncoder, error) {
+	pieces, padding, err := kodr_internals.OriginalPiecesFromDataAndPieceCount(data, pieceCount)
+	if err != nil {
+		return nil, err
+	}
+
+	enc, err := NewPerpetualEncoder(pieces, width, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	enc.extra = padding
+	return enc, nil
+}
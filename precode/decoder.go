@@ -0,0 +1,106 @@
+package precode
+
+import (
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/fountain"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+)
+
+// Decoder for coded pieces, produced by any encoder run over precoded
+// i.e. K+P intermediate pieces. Parity checks are loaded as zero valued
+// equations, as soon as piece size is known ( i.e. on first coded piece ),
+// then received coded pieces are peeled, while Gaussian elimination is
+// run over still unknown intermediate pieces, only when peeling stalls
+//
+// Decoding succeeds when source pieces are recovered, which usually
+// needs only slightly more than K coded pieces
+type PrecodeDecoder struct {
+	precode *Precode
+	decoder *fountain.LTDecoder
+	loaded  bool
+}
+
+// IsDecoded - Use it for checking whether more piece
+// collection is required or not
+func (p *PrecodeDecoder) IsDecoded() bool {
+	return p.decoder.IsDecoded()
+}
+
+// Required - At least how many more coded pieces are required
+// for successfully decoding source pieces ?
+func (p *PrecodeDecoder) Required() uint {
+	if !p.loaded {
+		return p.precode.SourceCount()
+	}
+	return p.decoder.Required()
+}
+
+// Received - How many coded pieces are received so far, including
+// linearly dependent ones
+func (p *PrecodeDecoder) Received() uint {
+	return p.decoder.Received()
+}
+
+// Stats - #-of intermediate pieces decoded by peeling & by
+// Gaussian elimination, respectively
+func (p *PrecodeDecoder) Stats() (uint, uint) {
+	return p.decoder.Stats()
+}
+
+// AddPiece - Adds a new received coded piece, whose coding vector
+// is over all K+P intermediate pieces
+func (p *PrecodeDecoder) AddPiece(piece *kodr_internals.CodedPiece) error {
+	if p.IsDecoded() {
+		return kodr.ErrAllUsefulPiecesReceived
+	}
+	if uint(len(piece.Vector)) != p.precode.PieceCount() {
+		return kodr.ErrCodingVectorLengthMismatch
+	}
+
+	if !p.loaded {
+		for _, constraint := range p.precode.Constraints() {
+			if err := p.decoder.AddConstraint(constraint, uint(len(piece.Piece))); err != nil {
+				return err
+			}
+		}
+		p.loaded = true
+	}
+
+	return p.decoder.AddPiece(piece)
+}
+
+// GetPiece - Get a decoded source piece by index, may ( not ) succeed !
+func (p *PrecodeDecoder) GetPiece(i uint) (kodr_internals.Piece, error) {
+	if i >= p.precode.SourceCount() {
+		return nil, kodr.ErrPieceOutOfBound
+	}
+	return p.decoder.GetPiece(i)
+}
+
+// GetPieces - Get a list of all decoded source pieces, given full
+// decoding has happened
+func (p *PrecodeDecoder) GetPieces() ([]kodr_internals.Piece, error) {
+	pieces, err := p.decoder.GetPieces()
+	if err != nil {
+		return nil, err
+	}
+	return pieces[:p.precode.SourceCount()], nil
+}
+
+// Decoder for pieces coded over intermediate pieces of given precode,
+// where elimination is tried whenever peeling stalls, with enough pending
+// equations, so that overhead stays minimal
+func NewPrecodeDecoder(precode *Precode) *PrecodeDecoder {
+	return NewPrecodeDecoderWithFallbackOverhead(precode, 0)
+}
+
+// Same as `NewPrecodeDecoder`, but Gaussian elimination is tried only
+// after ( 1 + overhead ) * ( K+P ) equations ( received coded pieces &
+// parity checks ) are collected, which suits LT encoder on top, as
+// peeling alone mostly succeeds
+func NewPrecodeDecoderWithFallbackOverhead(precode *Precode, overhead float64) *PrecodeDecoder {
+	return &PrecodeDecoder{
+		precode: precode,
+		decoder: fountain.NewLTDecoderWithFallbackOverhead(precode.PieceCount(), overhead),
+	}
+}
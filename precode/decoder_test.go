@@ -0,0 +1,112 @@
+package precode_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/fountain"
+	"github.com/itzmeanjan/kodr/full"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/precode"
+)
+
+// Keeps feeding coded pieces to decoder until it's able to decode,
+// then compares decoded source pieces with original ones
+func decoderFlow(t *testing.T, next func() *kodr_internals.CodedPiece, dec *precode.PrecodeDecoder, pieces []kodr_internals.Piece) {
+	for {
+		if err := dec.AddPiece(next()); errors.Is(err, kodr.ErrAllUsefulPiecesReceived) {
+			break
+		} else if err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	d_pieces, err := dec.GetPieces()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if len(pieces) != len(d_pieces) {
+		t.Fatal("didn't decode all !")
+	}
+
+	for i := range pieces {
+		if !bytes.Equal(pieces[i], d_pieces[i]) {
+			t.Fatal("decoded data doesn't match !")
+		}
+	}
+}
+
+func TestPrecodeWithFullRLNCEncoder(t *testing.T) {
+	var (
+		sourceCount uint = 64
+		parityCount uint = 5
+		pieces           = generatePieces(sourceCount, 1<<10)
+	)
+
+	for name, build := range map[string]func(uint, uint) (*precode.Precode, error){
+		"ldpc":  precode.NewLDPCPrecode,
+		"dense": precode.NewDensePrecode,
+	} {
+		t.Run(name, func(t *testing.T) {
+			pre, err := build(sourceCount, parityCount)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+
+			precoded, err := pre.Encode(pieces)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+
+			enc := full.NewFullRLNCEncoder(precoded)
+			dec := precode.NewPrecodeDecoder(pre)
+			decoderFlow(t, enc.CodedPiece, dec, pieces)
+
+			// parity checks stand in for P coded pieces
+			if dec.Received() >= pre.PieceCount() {
+				t.Fatalf("received %d coded pieces, expected < %d\n", dec.Received(), pre.PieceCount())
+			}
+		})
+	}
+}
+
+func TestPrecodeWithLTEncoder(t *testing.T) {
+	var (
+		sourceCount uint = 1000
+		parityCount uint = 23
+		pieces           = generatePieces(sourceCount, 64)
+		rounds           = 10
+	)
+
+	pre, err := precode.NewLDPCPrecode(sourceCount, parityCount)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	precoded, err := pre.Encode(pieces)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	dist, err := fountain.NewRobustSoliton(pre.PieceCount(), 0.03, 0.5)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	enc := fountain.NewLTEncoder(precoded, dist)
+
+	var received uint
+	for range rounds {
+		dec := precode.NewPrecodeDecoderWithFallbackOverhead(pre, 0.05)
+		decoderFlow(t, enc.CodedPiece, dec, pieces)
+		received += dec.Received()
+
+		if peeled, eliminated := dec.Stats(); peeled+eliminated != pre.PieceCount() {
+			t.Fatalf("%d intermediate pieces decoded, expected %d\n", peeled+eliminated, pre.PieceCount())
+		}
+	}
+
+	t.Logf("received %.1f coded pieces on average, for %d source pieces\n", float64(received)/float64(rounds), sourceCount)
+}
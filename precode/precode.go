@@ -0,0 +1,129 @@
+// Package precode implements Raptor-like precoding, where a small number
+// of parity pieces, computed from K source pieces, are appended to them
+// before any ( sparse ) encoder is run over resulting K+P intermediate
+// pieces. Parity checks are known to decoder, which uses them as extra
+// equations, so that far fewer than K+P coded pieces need to be received.
+package precode
+
+import (
+	"math/rand"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+)
+
+type Precode struct {
+	sourceCount uint
+	// One row per parity piece, each having one GF(2^8) coefficient
+	// per source piece i.e. parity_j = Σ checks[j][i] * source_i
+	checks [][]byte
+}
+
+// #-of source pieces, precode is built for
+func (p *Precode) SourceCount() uint {
+	return p.sourceCount
+}
+
+// #-of parity pieces, appended to source pieces
+func (p *Precode) ParityCount() uint {
+	return uint(len(p.checks))
+}
+
+// #-of intermediate pieces i.e. source + parity pieces, which
+// are to be coded together, by encoder sitting on top
+func (p *Precode) PieceCount() uint {
+	return p.sourceCount + p.ParityCount()
+}
+
+// Parity check equations, over intermediate pieces, each of which
+// must combine to zero i.e. Σ checks[j][i] * source_i - parity_j = 0
+//
+// Returned coding vectors are freshly allocated
+func (p *Precode) Constraints() []kodr_internals.CodingVector {
+	f := field.Default()
+	constraints := make([]kodr_internals.CodingVector, 0, p.ParityCount())
+	for j, check := range p.checks {
+		vector := make(kodr_internals.CodingVector, p.PieceCount())
+		copy(vector, check)
+		vector[p.sourceCount+uint(j)] = byte(f.Sub(0, 1))
+		constraints = append(constraints, vector)
+	}
+	return constraints
+}
+
+// Computes parity pieces from given source pieces & returns all K+P
+// intermediate pieces, source pieces first, untouched. Returned slice
+// can be handed over to any encoder constructor, accepting pieces i.e.
+// `full.NewFullRLNCEncoder(precoded)`
+func (p *Precode) Encode(pieces []kodr_internals.Piece) ([]kodr_internals.Piece, error) {
+	if uint(len(pieces)) != p.sourceCount {
+		return nil, kodr.ErrPieceCountMismatch
+	}
+
+	f := field.Default()
+	precoded := make([]kodr_internals.Piece, 0, p.PieceCount())
+	precoded = append(precoded, pieces...)
+
+	for _, check := range p.checks {
+		parity := make(kodr_internals.Piece, len(pieces[0]))
+		for i, c := range check {
+			f.MulAddSlice(parity, pieces[i], uint32(c))
+		}
+		precoded = append(precoded, parity)
+	}
+
+	return precoded, nil
+}
+
+// LDPC precode, where each source piece is XOR-ed into ( upto ) 3 parity
+// pieces, following Raptor's circulant pattern i.e. for source piece i,
+// with a = 1 + ⌊i/P⌋ mod ( P-1 ), parity pieces at i mod P, + a, + 2a
+// ( mod P ) are touched. It's cheap to compute & sparse, so that peeling
+// decoder doesn't stall on it. Choosing P prime helps
+func NewLDPCPrecode(sourceCount, parityCount uint) (*Precode, error) {
+	if sourceCount == 0 || parityCount == 0 {
+		return nil, kodr.ErrBadPrecodeParameters
+	}
+
+	checks := make([][]byte, parityCount)
+	for j := range checks {
+		checks[j] = make([]byte, sourceCount)
+	}
+
+	for i := range sourceCount {
+		a := uint(1)
+		if parityCount > 1 {
+			a = 1 + (i/parityCount)%(parityCount-1)
+		}
+
+		b := i % parityCount
+		for range min(3, parityCount) {
+			checks[b][i] ^= 1
+			b = (b + a) % parityCount
+		}
+	}
+
+	return &Precode{sourceCount: sourceCount, checks: checks}, nil
+}
+
+// Dense GF(2^8) precode, where each parity piece is a combination of all
+// source pieces, with nonzero coefficients drawn from a pseudo-random
+// source seeded by ( K, P ), so that encoder & decoder agree on them,
+// without exchanging. Each parity check is almost surely innovative
+func NewDensePrecode(sourceCount, parityCount uint) (*Precode, error) {
+	if sourceCount == 0 || parityCount == 0 {
+		return nil, kodr.ErrBadPrecodeParameters
+	}
+
+	rng := rand.New(rand.NewSource(int64(sourceCount)<<32 | int64(parityCount)))
+	checks := make([][]byte, parityCount)
+	for j := range checks {
+		checks[j] = make([]byte, sourceCount)
+		for i := range checks[j] {
+			checks[j][i] = byte(1 + rng.Intn(255))
+		}
+	}
+
+	return &Precode{sourceCount: sourceCount, checks: checks}, nil
+}
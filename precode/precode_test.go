@@ -0,0 +1,117 @@
+package precode_test
+
+import (
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+	"github.com/itzmeanjan/kodr/precode"
+)
+
+// Generates N-many pieces each of M-bytes length, to be used
+// for testing purposes
+func generatePieces(pieceCount uint, pieceLength uint) []kodr_internals.Piece {
+	pieces := make([]kodr_internals.Piece, 0, pieceCount)
+	for range pieceCount {
+		piece := make(kodr_internals.Piece, pieceLength)
+		rand.Read(piece)
+		pieces = append(pieces, piece)
+	}
+	return pieces
+}
+
+// Every parity check must combine intermediate pieces to zero
+func checkConstraints(t *testing.T, pre *precode.Precode, precoded []kodr_internals.Piece) {
+	f := field.Default()
+	for j, constraint := range pre.Constraints() {
+		sum := make(kodr_internals.Piece, len(precoded[0]))
+		for i, c := range constraint {
+			f.MulAddSlice(sum, precoded[i], uint32(c))
+		}
+
+		for _, b := range sum {
+			if b != 0 {
+				t.Fatalf("parity check %d isn't satisfied\n", j)
+			}
+		}
+	}
+}
+
+func TestPrecodeParameters(t *testing.T) {
+	if _, err := precode.NewLDPCPrecode(0, 3); !errors.Is(err, kodr.ErrBadPrecodeParameters) {
+		t.Fatalf("expected: %s\n", kodr.ErrBadPrecodeParameters)
+	}
+	if _, err := precode.NewDensePrecode(16, 0); !errors.Is(err, kodr.ErrBadPrecodeParameters) {
+		t.Fatalf("expected: %s\n", kodr.ErrBadPrecodeParameters)
+	}
+
+	pre, err := precode.NewLDPCPrecode(16, 5)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := pre.Encode(generatePieces(15, 64)); !errors.Is(err, kodr.ErrPieceCountMismatch) {
+		t.Fatalf("expected: %s\n", kodr.ErrPieceCountMismatch)
+	}
+}
+
+func TestLDPCPrecode(t *testing.T) {
+	var (
+		sourceCount uint = 100
+		parityCount uint = 7
+	)
+
+	pre, err := precode.NewLDPCPrecode(sourceCount, parityCount)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if pre.PieceCount() != sourceCount+parityCount {
+		t.Fatalf("expected %d intermediate pieces, found %d\n", sourceCount+parityCount, pre.PieceCount())
+	}
+
+	// each source piece takes part in exactly 3 parity checks
+	constraints := pre.Constraints()
+	for i := range sourceCount {
+		degree := 0
+		for _, constraint := range constraints {
+			if constraint[i] != 0 {
+				degree++
+			}
+		}
+		if degree != 3 {
+			t.Fatalf("source piece %d is part of %d parity checks, expected 3\n", i, degree)
+		}
+	}
+
+	pieces := generatePieces(sourceCount, 64)
+	precoded, err := pre.Encode(pieces)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	checkConstraints(t, pre, precoded)
+}
+
+func TestDensePrecode(t *testing.T) {
+	var (
+		sourceCount uint = 300
+		parityCount uint = 4
+	)
+
+	pre, err := precode.NewDensePrecode(sourceCount, parityCount)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	pieces := generatePieces(sourceCount, 64)
+	precoded, err := pre.Encode(pieces)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	checkConstraints(t, pre, precoded)
+
+	// decoder side must be able to rebuild same precode
+	again, _ := precode.NewDensePrecode(sourceCount, parityCount)
+	checkConstraints(t, again, precoded)
+}
@@ -0,0 +1,211 @@
+package progressive
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"sync"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+)
+
+// Decoder - Any decoder, which reports original pieces as soon as those
+// become decodable, say `full.FullRLNCDecoder` or `systematic.SystematicRLNCDecoder`
+type Decoder interface {
+	AddPiece(piece *kodr_internals.CodedPiece) error
+	GetPiece(idx uint) (kodr_internals.Piece, error)
+	Decodable() []uint
+	OnDecodable(fn func(idx uint))
+	Rank() uint
+	Required() uint
+}
+
+// Delivery - Decoded original piece, along with its index
+type Delivery struct {
+	Index uint
+	Piece kodr_internals.Piece
+}
+
+// Wraps decoder, delivering decoded original pieces in order, i.e.
+// i-th piece is delivered as soon as it & all pieces before it are
+// decodable, which is what media playback needs, instead of waiting
+// for whole generation to be decoded
+type InOrderDecoder struct {
+	ctx        context.Context
+	dec        Decoder
+	pieceCount uint
+	ready      []bool
+	next       uint
+	out        chan Delivery
+	lock       sync.Mutex
+	closed     bool
+	stop       func() bool
+	decodable  func(idx uint)
+	reported   []uint
+}
+
+// Total #-of pieces being coded together
+func (d *InOrderDecoder) PieceCount() uint {
+	return d.pieceCount
+}
+
+// #-of leading original pieces, delivered so far
+func (d *InOrderDecoder) Delivered() uint {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return d.next
+}
+
+// AddPiece - Adds a new received coded piece to wrapped decoder & delivers
+// original pieces, which can now be delivered in order. Safe to be invoked
+// concurrently with consumption of delivered pieces
+//
+// Once all pieces are delivered, returns error denoting no more pieces are
+// required, while if context is cancelled, returns context's error
+func (d *InOrderDecoder) AddPiece(piece *kodr_internals.CodedPiece) error {
+	d.lock.Lock()
+	err := d.add(piece)
+	reported, fn := d.reported, d.decodable
+	d.reported = nil
+	d.lock.Unlock()
+
+	// invoked without lock held, so that callback can query decoder
+	if fn != nil {
+		for _, idx := range reported {
+			fn(idx)
+		}
+	}
+	return err
+}
+
+// Note: Lock must be held by caller
+func (d *InOrderDecoder) add(piece *kodr_internals.CodedPiece) error {
+	if err := d.ctx.Err(); err != nil {
+		return err
+	}
+	if d.closed {
+		return kodr.ErrAllUsefulPiecesReceived
+	}
+
+	// wrapped decoder may already be done, though leading pieces
+	// still need to be delivered
+	err := d.dec.AddPiece(piece)
+	if err != nil && !errors.Is(err, kodr.ErrAllUsefulPiecesReceived) {
+		return err
+	}
+
+	d.release()
+	return err
+}
+
+// OnDecodable - Registers callback, invoked with index of each original
+// piece, as soon as wrapped decoder can decode it, irrespective of order.
+// It's invoked from within `AddPiece`, after pieces are delivered, while
+// lock isn't held, so that it can call `Delivered` or `AddPiece` itself
+//
+// Note: Wrapped decoder's own callback is taken over by this wrapper,
+// so register callback here, instead of on wrapped decoder
+func (d *InOrderDecoder) OnDecodable(fn func(idx uint)) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.decodable = fn
+}
+
+// Delivers leading pieces, as far as those are decodable, closing
+// channel once last one is delivered
+//
+// Note: Lock must be held by caller
+func (d *InOrderDecoder) release() {
+	for d.next < d.pieceCount && d.ready[d.next] {
+		// reported decodable, so it mustn't fail
+		piece, err := d.dec.GetPiece(d.next)
+		if err != nil {
+			return
+		}
+
+		// channel can hold all pieces, so it never blocks
+		d.out <- Delivery{Index: d.next, Piece: piece}
+		d.next++
+	}
+
+	if d.next == d.pieceCount && !d.closed {
+		d.closed = true
+		close(d.out)
+		d.stop()
+	}
+}
+
+// Channel of decoded original pieces, in order, which is closed once
+// all pieces are delivered or context is cancelled
+//
+// Note: Pieces delivered before cancellation can still be received
+// from closed channel
+func (d *InOrderDecoder) Deliveries() <-chan Delivery {
+	return d.out
+}
+
+// Iterator over decoded original pieces, in order, as (index, piece)
+// pairs, which blocks until next piece is delivered, while it stops
+// as soon as all pieces are delivered or context is cancelled
+func (d *InOrderDecoder) All() iter.Seq2[uint, kodr_internals.Piece] {
+	return func(yield func(uint, kodr_internals.Piece) bool) {
+		for delivery := range d.out {
+			if d.ctx.Err() != nil {
+				return
+			}
+			if !yield(delivery.Index, delivery.Piece) {
+				return
+			}
+		}
+	}
+}
+
+// Wraps decoder, so that decoded original pieces are delivered in order,
+// until all pieces are delivered or given context is cancelled. Pieces,
+// which wrapped decoder can already decode, are delivered right away
+//
+// Note: Callback registered on wrapped decoder using `OnDecodable` is
+// replaced, use `InOrderDecoder.OnDecodable` instead
+func NewInOrderDecoder(ctx context.Context, dec Decoder) *InOrderDecoder {
+	pieceCount := dec.Rank() + dec.Required()
+	d := &InOrderDecoder{
+		ctx:        ctx,
+		dec:        dec,
+		pieceCount: pieceCount,
+		ready:      make([]bool, pieceCount),
+		out:        make(chan Delivery, pieceCount),
+	}
+
+	dec.OnDecodable(func(idx uint) {
+		// invoked from within `AddPiece`, while lock is held, so
+		// only recorded, to be reported once lock is released
+		d.ready[idx] = true
+		d.reported = append(d.reported, idx)
+	})
+
+	d.stop = context.AfterFunc(ctx, func() {
+		d.lock.Lock()
+		defer d.lock.Unlock()
+
+		if !d.closed {
+			d.closed = true
+			close(d.out)
+		}
+	})
+
+	// decoder may have already reported these, before being wrapped,
+	// so those won't be reported again
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	for _, idx := range dec.Decodable() {
+		d.ready[idx] = true
+	}
+	if ctx.Err() == nil {
+		d.release()
+	}
+	return d
+}
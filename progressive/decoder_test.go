@@ -0,0 +1,205 @@
+package progressive_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	math_rand "math/rand"
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/full"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/progressive"
+	"github.com/itzmeanjan/kodr/systematic"
+)
+
+func generatePieces(pieceCount uint, pieceLength uint) []kodr_internals.Piece {
+	pieces := make([]kodr_internals.Piece, 0, pieceCount)
+	for range pieceCount {
+		piece := make(kodr_internals.Piece, pieceLength)
+		rand.Read(piece)
+		pieces = append(pieces, piece)
+	}
+	return pieces
+}
+
+// Lossy systematic phase, followed by random coded pieces, while consumer
+// iterates over delivered pieces concurrently, which must arrive in order,
+// some of them well before whole generation is decoded
+func TestInOrderDecoder(t *testing.T) {
+	var (
+		pieceCount  uint = 64
+		pieceLength uint = 256
+		pieces           = generatePieces(pieceCount, pieceLength)
+		enc              = systematic.NewSystematicRLNCEncoder(pieces)
+		dec              = full.NewFullRLNCDecoder(pieceCount)
+		in_order         = progressive.NewInOrderDecoder(context.Background(), dec)
+	)
+
+	done := make(chan uint)
+	go func() {
+		var next uint
+		for idx, piece := range in_order.All() {
+			if idx != next {
+				t.Errorf("expected piece %d, delivered %d\n", next, idx)
+			}
+			if !bytes.Equal(piece, pieces[idx]) {
+				t.Error("decoded data doesn't match !")
+			}
+			next++
+		}
+		done <- next
+	}()
+
+	// first piece always makes it through, so that something can be
+	// delivered early
+	early := uint(0)
+	for i := 0; ; i++ {
+		c_piece := enc.CodedPiece()
+		if i > 0 && math_rand.Intn(10) < 2 {
+			continue
+		}
+
+		err := in_order.AddPiece(c_piece)
+		if errors.Is(err, kodr.ErrAllUsefulPiecesReceived) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if !dec.IsDecoded() && early == 0 {
+			early = in_order.Delivered()
+		}
+	}
+
+	if delivered := <-done; delivered != pieceCount {
+		t.Fatalf("expected %d pieces delivered, found %d\n", pieceCount, delivered)
+	}
+	if in_order.Delivered() != pieceCount {
+		t.Fatalf("expected %d pieces delivered, found %d\n", pieceCount, in_order.Delivered())
+	}
+	if early == 0 {
+		t.Fatal("expected some pieces to be delivered before full decoding")
+	}
+}
+
+func TestInOrderDecoderCancel(t *testing.T) {
+	var (
+		pieceCount  uint = 32
+		pieceLength uint = 64
+		pieces           = generatePieces(pieceCount, pieceLength)
+		enc              = systematic.NewSystematicRLNCEncoder(pieces)
+		ctx, cancel      = context.WithCancel(context.Background())
+		in_order         = progressive.NewInOrderDecoder(ctx, systematic.NewSystematicRLNCDecoder(pieceCount))
+	)
+
+	for range pieceCount / 2 {
+		if err := in_order.AddPiece(enc.CodedPiece()); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+	cancel()
+
+	if err := in_order.AddPiece(enc.CodedPiece()); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected: %s\n", context.Canceled)
+	}
+
+	// already delivered ones are still buffered in channel, which is closed
+	delivered := uint(0)
+	for delivery := range in_order.Deliveries() {
+		if delivery.Index != delivered || !bytes.Equal(delivery.Piece, pieces[delivered]) {
+			t.Fatal("decoded data doesn't match !")
+		}
+		delivered++
+	}
+	if delivered != pieceCount/2 {
+		t.Fatalf("expected %d pieces delivered, found %d\n", pieceCount/2, delivered)
+	}
+
+	for range in_order.All() {
+		t.Fatal("iterator must stop after cancellation")
+	}
+}
+
+// Wrapped decoder has already decoded some pieces, which it reported
+// before being wrapped, those must be delivered right away
+func TestInOrderDecoderWrappingPartlyDecoded(t *testing.T) {
+	var (
+		pieceCount  uint = 32
+		pieceLength uint = 64
+		pieces           = generatePieces(pieceCount, pieceLength)
+		enc              = systematic.NewSystematicRLNCEncoder(pieces)
+		dec              = systematic.NewSystematicRLNCDecoder(pieceCount)
+	)
+
+	reported := uint(0)
+	dec.OnDecodable(func(idx uint) { reported++ })
+	for range pieceCount / 2 {
+		if err := dec.AddPiece(enc.CodedPiece()); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+	if reported != pieceCount/2 {
+		t.Fatalf("expected %d pieces reported, found %d\n", pieceCount/2, reported)
+	}
+
+	in_order := progressive.NewInOrderDecoder(context.Background(), dec)
+	if in_order.Delivered() != pieceCount/2 {
+		t.Fatalf("expected %d pieces delivered, found %d\n", pieceCount/2, in_order.Delivered())
+	}
+
+	decodable := uint(0)
+	in_order.OnDecodable(func(idx uint) {
+		// invoked without lock held, after delivering, while remaining
+		// systematic pieces arrive in order
+		if delivered := in_order.Delivered(); delivered <= idx {
+			t.Errorf("piece %d reported, while only %d delivered\n", idx, delivered)
+		}
+		decodable++
+	})
+	for {
+		err := in_order.AddPiece(enc.CodedPiece())
+		if errors.Is(err, kodr.ErrAllUsefulPiecesReceived) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+	if decodable != pieceCount/2 {
+		t.Fatalf("expected %d pieces reported by wrapper, found %d\n", pieceCount/2, decodable)
+	}
+
+	delivered := uint(0)
+	for idx, piece := range in_order.All() {
+		if idx != delivered || !bytes.Equal(piece, pieces[idx]) {
+			t.Fatal("decoded data doesn't match !")
+		}
+		delivered++
+	}
+	if delivered != pieceCount {
+		t.Fatalf("expected %d pieces delivered, found %d\n", pieceCount, delivered)
+	}
+
+	// fully decoded one is delivered as a whole, while wrapping
+	full_dec := full.NewFullRLNCDecoder(pieceCount)
+	full_enc := full.NewFullRLNCEncoder(pieces)
+	for !full_dec.IsDecoded() {
+		if err := full_dec.AddPiece(full_enc.CodedPiece()); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	in_order = progressive.NewInOrderDecoder(context.Background(), full_dec)
+	if in_order.Delivered() != pieceCount {
+		t.Fatalf("expected %d pieces delivered, found %d\n", pieceCount, in_order.Delivered())
+	}
+	if err := in_order.AddPiece(full_enc.CodedPiece()); !errors.Is(err, kodr.ErrAllUsefulPiecesReceived) {
+		t.Fatalf("expected: %s\n", kodr.ErrAllUsefulPiecesReceived)
+	}
+	if delivered := len(in_order.Deliveries()); uint(delivered) != pieceCount {
+		t.Fatalf("expected %d buffered deliveries, found %d\n", pieceCount, delivered)
+	}
+}
@@ -1,14 +1,21 @@
 package systematic
 
 import (
+	"encoding/binary"
+
 	"github.com/itzmeanjan/kodr"
 	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf65536"
 	"github.com/itzmeanjan/kodr/kodr_internals/matrix"
 )
 
 type SystematicRLNCDecoder struct {
 	expected, useful, received uint
-	state                      *matrix.DecoderState
+	version                    uint64
+	state                      matrix.State
+	reported                   []bool
+	onDecodable                func(idx uint)
 }
 
 // Each piece of N-many bytes
@@ -16,12 +23,7 @@ type SystematicRLNCDecoder struct {
 // Note: If no pieces are yet added to decoder state, then
 // returns 0, denoting **unknown**
 func (s *SystematicRLNCDecoder) PieceLength() uint {
-	if s.received > 0 {
-		coded := s.state.CodedPieceMatrix()
-		return coded.Cols()
-	}
-
-	return 0
+	return s.state.PieceLength()
 }
 
 // Already decoded back to original pieces, with collected pieces ?
@@ -39,6 +41,17 @@ func (s *SystematicRLNCDecoder) Required() uint {
 	return s.expected - s.useful
 }
 
+// Rank - How many linearly independent pieces are received so far
+func (s *SystematicRLNCDecoder) Rank() uint {
+	return s.useful
+}
+
+// Feedback - Serialized rank of decoder, which can be sent back to
+// sender, so that it can adapt coding density to decoder's progress
+func (s *SystematicRLNCDecoder) Feedback() []byte {
+	return binary.AppendUvarint(nil, uint64(s.Rank()))
+}
+
 // Add one more collected coded piece, which will be used for decoding
 // back to original pieces
 //
@@ -49,18 +62,79 @@ func (s *SystematicRLNCDecoder) AddPiece(piece *kodr_internals.CodedPiece) error
 		return kodr.ErrAllUsefulPiecesReceived
 	}
 
+	// piece coded over some other field, would only corrupt decoder state
+	if piece.Field != 0 && piece.Field != s.state.Field().ID() {
+		return kodr.ErrFieldMismatch
+	}
+
+	if uint(len(piece.Vector)) != s.expected*s.state.Field().SymbolSize() {
+		return kodr.ErrCodingVectorLengthMismatch
+	}
+	if l := s.state.PieceLength(); l != 0 && uint(len(piece.Piece)) != l {
+		return kodr.ErrCodedDataLengthMismatch
+	}
+
+	// pieces coded from different versions of object, mustn't be mixed
+	if s.received > 0 && piece.Version != s.version {
+		return kodr.ErrVersionMismatch
+	}
+	s.version = piece.Version
+
 	s.state.AddPiece(piece)
 	s.received++
 	if !(s.received > 1) {
 		s.useful++
+		s.notify()
 		return nil
 	}
 
 	s.state.Rref()
 	s.useful = s.state.Rank()
+	s.notify()
 	return nil
 }
 
+// Decodable - Indices of original pieces, which are decodable right now,
+// in increasing order, so that those can be consumed using `GetPiece`,
+// before full rank is reached
+func (s *SystematicRLNCDecoder) Decodable() []uint {
+	return s.state.Decodable()
+}
+
+// OnDecodable - Registers callback, which is invoked with index of each
+// original piece, as soon as it becomes decodable, while adding coded
+// pieces. Pieces, which are already decodable, are reported on next
+// addition
+func (s *SystematicRLNCDecoder) OnDecodable(fn func(idx uint)) {
+	s.onDecodable = fn
+}
+
+// Reports original pieces, which became decodable since last call,
+// in increasing order, if some callback is registered
+func (s *SystematicRLNCDecoder) notify() {
+	if s.onDecodable == nil {
+		return
+	}
+	if s.reported == nil {
+		s.reported = make([]bool, s.expected)
+	}
+
+	for _, idx := range s.state.Decodable() {
+		if s.reported[idx] {
+			continue
+		}
+		s.reported[idx] = true
+		s.onDecodable(idx)
+	}
+}
+
+// Summary - Compact summary of subspace spanned by received coding
+// vectors, which can be sent back to sender, so that it crafts coded
+// pieces guaranteed to be innovative, using `CodedPieceFor`
+func (s *SystematicRLNCDecoder) Summary() *matrix.Summary {
+	return s.state.Summary()
+}
+
 // GetPiece - Get a decoded piece by index, may ( not ) succeed !
 //
 // Note: It's not necessary that full decoding needs to happen
@@ -106,3 +180,23 @@ func NewSystematicRLNCDecoder(pieceCount uint) *SystematicRLNCDecoder {
 	state := matrix.NewDecoderStateWithPieceCount(pieceCount)
 	return &SystematicRLNCDecoder{expected: pieceCount, state: state}
 }
+
+// Same as `NewSystematicRLNCDecoder`, but for decoding pieces
+// coded ( or recoded ) over given finite field
+func NewSystematicRLNCDecoderWithField(pieceCount uint, f field.Field) *SystematicRLNCDecoder {
+	state := matrix.NewDecoderStateWithField(pieceCount, f)
+	return &SystematicRLNCDecoder{expected: pieceCount, state: state}
+}
+
+// Same as `NewSystematicRLNCDecoder`, but elimination is carried out by
+// given decoder state, say `matrix.SparseDecoderState`, for which
+// systematic ( unit ) coding vectors cost nothing to eliminate
+func NewSystematicRLNCDecoderWithState(pieceCount uint, state matrix.State) *SystematicRLNCDecoder {
+	return &SystematicRLNCDecoder{expected: pieceCount, state: state}
+}
+
+// Same as `NewSystematicRLNCDecoder`, but for decoding pieces
+// coded ( or recoded ) over GF(2^16)
+func NewSystematicRLNCDecoderGf65536(pieceCount uint) *SystematicRLNCDecoder {
+	return NewSystematicRLNCDecoderWithField(pieceCount, gf65536.DefaultField())
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/itzmeanjan/kodr"
 	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/matrix"
 	"github.com/itzmeanjan/kodr/systematic"
 )
 
@@ -52,3 +53,41 @@ func TestNewSystematicRLNCDecoder(t *testing.T) {
 		}
 	}
 }
+
+// Systematic pieces are unit vectors, which sparse decoder state
+// pivots on directly, without any elimination
+func TestSystematicRLNCDecoderWithSparseState(t *testing.T) {
+	var (
+		pieceCount  uint = 128
+		pieceLength uint = 1024
+		pieces           = generatePieces(pieceCount, pieceLength)
+		enc              = systematic.NewSystematicRLNCEncoder(pieces)
+		dec              = systematic.NewSystematicRLNCDecoderWithState(pieceCount, matrix.NewSparseDecoderStateWithPieceCount(pieceCount))
+	)
+
+	encoderFlow(t, enc, dec, pieceCount, pieces)
+}
+
+func TestSystematicRLNCDecoderLengthMismatch(t *testing.T) {
+	var (
+		pieceCount  uint = 8
+		pieceLength uint = 64
+		enc              = systematic.NewSystematicRLNCEncoder(generatePieces(pieceCount, pieceLength))
+		dec              = systematic.NewSystematicRLNCDecoder(pieceCount)
+	)
+
+	piece := enc.CodedPiece()
+	short := &kodr_internals.CodedPiece{Vector: piece.Vector[:pieceCount-1], Piece: piece.Piece}
+	if err := dec.AddPiece(short); !errors.Is(err, kodr.ErrCodingVectorLengthMismatch) {
+		t.Fatalf("expected: %s\n", kodr.ErrCodingVectorLengthMismatch)
+	}
+	if err := dec.AddPiece(piece); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	piece = enc.CodedPiece()
+	short = &kodr_internals.CodedPiece{Vector: piece.Vector, Piece: piece.Piece[:len(piece.Piece)-1]}
+	if err := dec.AddPiece(short); !errors.Is(err, kodr.ErrCodedDataLengthMismatch) {
+		t.Fatalf("expected: %s\n", kodr.ErrCodedDataLengthMismatch)
+	}
+}
@@ -1,13 +1,22 @@
 package systematic
 
 import (
+	"math/rand"
+	"slices"
+
+	"github.com/itzmeanjan/kodr"
 	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
+	"github.com/itzmeanjan/kodr/kodr_internals/gf65536"
+	"github.com/itzmeanjan/kodr/kodr_internals/matrix"
 )
 
 type SystematicRLNCEncoder struct {
 	currentPieceId uint
 	pieces         []kodr_internals.Piece
 	extra          uint
+	field          field.Field
+	version        uint64
 }
 
 // Total #-of pieces being coded together --- denoting
@@ -44,8 +53,11 @@ func (s *SystematicRLNCEncoder) DecodableLen() uint {
 //
 // Here N = len(pieces), original pieces which are
 // being coded together
+//
+// Note: Each coding coefficient takes `SymbolSize()` bytes of
+// chosen finite field, 1 byte for GF(2^8) & 2 bytes for GF(2^16)
 func (s *SystematicRLNCEncoder) CodedPieceLen() uint {
-	return s.PieceCount() + s.PieceSize()
+	return s.PieceCount()*s.field.SymbolSize() + s.PieceSize()
 }
 
 // If any extra padding bytes added at end of original
@@ -55,6 +67,37 @@ func (s *SystematicRLNCEncoder) Padding() uint {
 	return s.extra
 }
 
+// Version of object being coded, which is bumped by each `Update`
+// & carried by each coded piece
+func (s *SystematicRLNCEncoder) Version() uint64 {
+	return s.version
+}
+
+// Replaces original piece at index with given one, of same size, bumping
+// version of object, while returning delta, which holders of coded pieces
+// of previous version apply, to bring those up to date, instead of
+// re-encoding whole object
+//
+// Note: Given piece is copied, so caller can reuse it, while encoder keeps
+// its own slice of original pieces, so caller's slice is never modified
+func (s *SystematicRLNCEncoder) Update(idx uint, piece kodr_internals.Piece) (*kodr_internals.Delta, error) {
+	if idx >= s.PieceCount() {
+		return nil, kodr.ErrPieceOutOfBound
+	}
+	if uint(len(piece)) != s.PieceSize() {
+		return nil, kodr.ErrPieceSizeMismatch
+	}
+
+	delta := kodr_internals.NewDelta(idx, s.pieces[idx], piece, s.version+1, s.field)
+
+	updated := make(kodr_internals.Piece, len(piece))
+	copy(updated, piece)
+	s.pieces[idx] = updated
+	s.version++
+
+	return delta, nil
+}
+
 // Generates a systematic coded piece's coding vector, which has
 // only one non-zero element ( 1 )
 func (s *SystematicRLNCEncoder) systematicCodingVector(idx uint) kodr_internals.CodingVector {
@@ -62,8 +105,8 @@ func (s *SystematicRLNCEncoder) systematicCodingVector(idx uint) kodr_internals.
 		return nil
 	}
 
-	vector := make(kodr_internals.CodingVector, s.PieceCount())
-	vector[idx] = 1
+	vector := make(kodr_internals.CodingVector, s.PieceCount()*s.field.SymbolSize())
+	s.field.SetSymbol(vector, idx, 1)
 	return vector
 }
 
@@ -90,30 +133,63 @@ func (s *SystematicRLNCEncoder) CodedPiece() *kodr_internals.CodedPiece {
 
 		s.currentPieceId++
 		return &kodr_internals.CodedPiece{
-			Vector: vector,
-			Piece:  piece,
+			Vector:  vector,
+			Piece:   piece,
+			Field:   s.field.ID(),
+			Version: s.version,
 		}
 	}
 
-	vector := kodr_internals.GenerateCodingVector(s.PieceCount())
+	vector := s.field.RandomVector(s.PieceCount())
 	piece := make(kodr_internals.Piece, s.PieceSize())
 
 	for i := range s.pieces {
-		piece.Multiply(s.pieces[i], vector[i])
+		s.field.MulAddSlice(piece, s.pieces[i], s.field.Symbol(vector, uint(i)))
 	}
 
 	return &kodr_internals.CodedPiece{
-		Vector: vector,
-		Piece:  piece,
+		Vector:  vector,
+		Piece:   piece,
+		Field:   s.field.ID(),
+		Version: s.version,
 	}
 }
 
+// Returns a coded piece, which is guaranteed to raise rank of decoder,
+// whose received subspace is summarized. It's simply one of original
+// pieces, which decoder can't decode yet i.e. whose column has no pivot,
+// sent uncoded, which is innovative & costs nothing to encode/ decode
+//
+// If summarized decoder has already received N linearly independent
+// pieces, returns error, as nothing can be innovative for it
+func (s *SystematicRLNCEncoder) CodedPieceFor(summary *matrix.Summary) (*kodr_internals.CodedPiece, error) {
+	if summary.PieceCount() != s.PieceCount() || summary.Field().ID() != s.field.ID() {
+		return nil, kodr.ErrSummaryMismatch
+	}
+
+	free := summary.Free()
+	if len(free) == 0 {
+		return nil, kodr.ErrAllUsefulPiecesReceived
+	}
+
+	idx := free[rand.Intn(len(free))]
+	piece := make(kodr_internals.Piece, s.PieceSize())
+	copy(piece, s.pieces[idx])
+
+	return &kodr_internals.CodedPiece{
+		Vector:  s.systematicCodingVector(idx),
+		Piece:   piece,
+		Field:   s.field.ID(),
+		Version: s.version,
+	}, nil
+}
+
 // When you've already splitted original data chunk into pieces
 // of same length ( in terms of bytes ), this function can be used
 // for creating one systematic RLNC encoder, which delivers coded pieces
 // on-the-fly
 func NewSystematicRLNCEncoder(pieces []kodr_internals.Piece) *SystematicRLNCEncoder {
-	return &SystematicRLNCEncoder{currentPieceId: 0, pieces: pieces}
+	return &SystematicRLNCEncoder{currentPieceId: 0, pieces: slices.Clone(pieces), field: field.Default()}
 }
 
 // If you know #-of pieces you want to code together, invoking
@@ -144,3 +220,71 @@ func NewSystematicRLNCEncoderWithPieceSize(data []byte, pieceSize uint) (*System
 	enc.extra = padding
 	return enc, nil
 }
+
+// Same as `NewSystematicRLNCEncoder`, but coding happens over given
+// finite field, which is why size of original pieces must be a multiple
+// of field's symbol size
+func NewSystematicRLNCEncoderWithField(pieces []kodr_internals.Piece, f field.Field) (*SystematicRLNCEncoder, error) {
+	for i := range pieces {
+		if uint(len(pieces[i]))%f.SymbolSize() != 0 {
+			return nil, kodr.ErrPieceSizeNotMultipleOfSymbolSize
+		}
+	}
+
+	return &SystematicRLNCEncoder{currentPieceId: 0, pieces: slices.Clone(pieces), field: f}, nil
+}
+
+// Splits whole data chunk into N-pieces, each of length multiple of
+// symbol size, with padding bytes appended at end of last piece, if
+// required & prepares systematic RLNC encoder, working over given finite field
+func NewSystematicRLNCEncoderWithFieldAndPieceCount(data []byte, pieceCount uint, f field.Field) (*SystematicRLNCEncoder, error) {
+	pieces, padding, err := kodr_internals.OriginalPiecesFromDataAndPieceCountWithSymbolSize(data, pieceCount, f.SymbolSize())
+	if err != nil {
+		return nil, err
+	}
+
+	enc, err := NewSystematicRLNCEncoderWithField(pieces, f)
+	if err != nil {
+		return nil, err
+	}
+
+	enc.extra = padding
+	return enc, nil
+}
+
+// Splits whole data chunk into pieces of N-bytes each, where N must be a
+// multiple of symbol size & prepares systematic RLNC encoder, working
+// over given finite field
+func NewSystematicRLNCEncoderWithFieldAndPieceSize(data []byte, pieceSize uint, f field.Field) (*SystematicRLNCEncoder, error) {
+	pieces, padding, err := kodr_internals.OriginalPiecesFromDataAndPieceSizeWithSymbolSize(data, pieceSize, f.SymbolSize())
+	if err != nil {
+		return nil, err
+	}
+
+	enc, err := NewSystematicRLNCEncoderWithField(pieces, f)
+	if err != nil {
+		return nil, err
+	}
+
+	enc.extra = padding
+	return enc, nil
+}
+
+// Same as `NewSystematicRLNCEncoder`, but coding happens over GF(2^16),
+// which is why size of original pieces must be a multiple of 2
+func NewSystematicRLNCEncoderGf65536(pieces []kodr_internals.Piece) (*SystematicRLNCEncoder, error) {
+	return NewSystematicRLNCEncoderWithField(pieces, gf65536.DefaultField())
+}
+
+// Splits whole data chunk into N-pieces, each of even length, with padding
+// bytes appended at end of last piece, if required & prepares systematic
+// RLNC encoder, working over GF(2^16)
+func NewSystematicRLNCEncoderGf65536WithPieceCount(data []byte, pieceCount uint) (*SystematicRLNCEncoder, error) {
+	return NewSystematicRLNCEncoderWithFieldAndPieceCount(data, pieceCount, gf65536.DefaultField())
+}
+
+// Splits whole data chunk into pieces of N-bytes each, where N must be a
+// multiple of 2 & prepares systematic RLNC encoder, working over GF(2^16)
+func NewSystematicRLNCEncoderGf65536WithPieceSize(data []byte, pieceSize uint) (*SystematicRLNCEncoder, error) {
+	return NewSystematicRLNCEncoderWithFieldAndPieceSize(data, pieceSize, gf65536.DefaultField())
+}
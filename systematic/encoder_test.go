@@ -10,6 +10,7 @@ import (
 
 	"github.com/itzmeanjan/kodr"
 	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/field"
 	"github.com/itzmeanjan/kodr/systematic"
 )
 
@@ -271,3 +272,97 @@ func TestSystematicRLNCEncoder_DecodableLen(t *testing.T) {
 		flow(enc, dec)
 	})
 }
+
+// Missing original pieces are served uncoded, each of which
+// must raise decoder's rank
+func TestSystematicRLNCEncoderCodedPieceFor(t *testing.T) {
+	var (
+		pieceCount  uint = 32
+		pieceLength uint = 64
+		pieces           = generatePieces(pieceCount, pieceLength)
+		enc              = systematic.NewSystematicRLNCEncoder(pieces)
+		dec              = systematic.NewSystematicRLNCDecoder(pieceCount)
+	)
+
+	// half of pieces are lost on the way
+	for range pieceCount / 2 {
+		if err := dec.AddPiece(enc.CodedPiece()); err != nil {
+			t.Fatal(err.Error())
+		}
+		enc.CodedPiece()
+	}
+
+	for !dec.IsDecoded() {
+		rank := dec.Rank()
+		c_piece, err := enc.CodedPieceFor(dec.Summary())
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if err := dec.AddPiece(c_piece); err != nil {
+			t.Fatal(err.Error())
+		}
+		if dec.Rank() != rank+1 {
+			t.Fatalf("expected rank %d, found %d\n", rank+1, dec.Rank())
+		}
+	}
+
+	d_pieces, err := dec.GetPieces()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	for i := range pieces {
+		if !bytes.Equal(pieces[i], d_pieces[i]) {
+			t.Fatal("decoded data doesn't match !")
+		}
+	}
+
+	if _, err := enc.CodedPieceFor(dec.Summary()); !errors.Is(err, kodr.ErrAllUsefulPiecesReceived) {
+		t.Fatalf("expected: %s\n", kodr.ErrAllUsefulPiecesReceived)
+	}
+}
+
+// Delta of updated piece must turn stored systematic copy of that piece
+// into updated one, while leaving copies of other pieces untouched
+func TestSystematicRLNCEncoderUpdate(t *testing.T) {
+	var (
+		pieceCount  uint = 8
+		pieceLength uint = 32
+		pieces           = generatePieces(pieceCount, pieceLength)
+		enc              = systematic.NewSystematicRLNCEncoder(pieces)
+	)
+
+	stored := make([]*kodr_internals.CodedPiece, 0, pieceCount)
+	for range pieceCount {
+		stored = append(stored, enc.CodedPiece())
+	}
+
+	updated := generatePieces(1, pieceLength)[0]
+	delta, err := enc.Update(5, updated)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if bytes.Equal(pieces[5], updated) {
+		t.Fatal("caller's slice of original pieces must be left untouched")
+	}
+
+	for i, c_piece := range stored {
+		if err := delta.Apply(c_piece, field.Default()); err != nil {
+			t.Fatal(err.Error())
+		}
+		if c_piece.Version != enc.Version() {
+			t.Fatalf("expected version %d, found %d\n", enc.Version(), c_piece.Version)
+		}
+
+		expected := pieces[i]
+		if i == 5 {
+			expected = updated
+		}
+		if !bytes.Equal(c_piece.Piece, expected) {
+			t.Fatalf("stored piece %d isn't up to date\n", i)
+		}
+	}
+
+	if c_piece := enc.CodedPiece(); c_piece.Version != 1 {
+		t.Fatalf("expected version 1, found %d\n", c_piece.Version)
+	}
+}
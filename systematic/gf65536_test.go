@@ -0,0 +1,49 @@
+package systematic_test
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/systematic"
+)
+
+func TestSystematicRLNCGf65536(t *testing.T) {
+	var (
+		pieceCount  uint = 128
+		pieceLength uint = 1024
+		pieces           = generatePieces(pieceCount, pieceLength)
+		dec              = systematic.NewSystematicRLNCDecoderGf65536(pieceCount)
+	)
+
+	enc, err := systematic.NewSystematicRLNCEncoderGf65536(pieces)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	for {
+		c_piece := enc.CodedPiece()
+
+		// simulate random coded_piece drop/ loss
+		if rand.Intn(2) == 0 {
+			continue
+		}
+
+		if err := dec.AddPiece(c_piece); errors.Is(err, kodr.ErrAllUsefulPiecesReceived) {
+			break
+		}
+	}
+
+	d_pieces, err := dec.GetPieces()
+	if err != nil {
+		t.Fatalf("Error: %s\n", err.Error())
+	}
+
+	for i := range pieces {
+		if !bytes.Equal(pieces[i], d_pieces[i]) {
+			t.Fatal("decoded data doesn't match !")
+		}
+	}
+}
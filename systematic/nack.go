@@ -0,0 +1,122 @@
+package systematic
+
+import (
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+)
+
+// Bitmap of ⌈N/8⌉ bytes, where i-th bit ( least significant first,
+// within each byte ) is set, iff i-th original piece is listed
+func bitmapOf(indices []uint, pieceCount uint) []byte {
+	bitmap := make([]byte, (pieceCount+7)/8)
+	for _, idx := range indices {
+		bitmap[idx/8] |= 1 << (idx % 8)
+	}
+	return bitmap
+}
+
+// MissingIndices - Indices of original pieces, requested using bitmap,
+// say one returned by decoder's `Missing`, in increasing order
+//
+// If bitmap isn't ⌈pieceCount/8⌉ bytes long or has some bit set at index
+// >= pieceCount, returns error
+func MissingIndices(bitmap []byte, pieceCount uint) ([]uint, error) {
+	if uint(len(bitmap)) != (pieceCount+7)/8 {
+		return nil, kodr.ErrMalformedBitmap
+	}
+
+	indices := make([]uint, 0, pieceCount)
+	for i := range uint(len(bitmap)) * 8 {
+		if bitmap[i/8]&(1<<(i%8)) == 0 {
+			continue
+		}
+		if i >= pieceCount {
+			return nil, kodr.ErrMalformedBitmap
+		}
+		indices = append(indices, i)
+	}
+	return indices, nil
+}
+
+// Missing - Compact bitmap of original pieces, which are not yet decoded,
+// which can be sent back to sender as negative acknowledgement, so that
+// it serves only those, using `UncodedPieces` or `TargetedCodedPiece`
+//
+// It's ⌈N/8⌉ bytes long, where i-th bit ( least significant first, within
+// each byte ) is set, iff i-th original piece isn't yet `Decodable`
+func (s *SystematicRLNCDecoder) Missing() []byte {
+	decoded := s.state.Decodable()
+
+	missing := make([]uint, 0, s.expected-uint(len(decoded)))
+	k := 0
+	for i := range s.expected {
+		if k < len(decoded) && decoded[k] == i {
+			k++
+			continue
+		}
+		missing = append(missing, i)
+	}
+	return bitmapOf(missing, s.expected)
+}
+
+// UncodedPieces - Returns each requested original piece in uncoded form,
+// which is what a receiver, missing only a few pieces, wants the most,
+// as those cost nothing to decode
+//
+// Requested pieces are listed using bitmap, as returned by decoder's `Missing`
+func (s *SystematicRLNCEncoder) UncodedPieces(bitmap []byte) ([]*kodr_internals.CodedPiece, error) {
+	indices, err := MissingIndices(bitmap, s.PieceCount())
+	if err != nil {
+		return nil, err
+	}
+
+	pieces := make([]*kodr_internals.CodedPiece, 0, len(indices))
+	for _, idx := range indices {
+		piece := make(kodr_internals.Piece, s.PieceSize())
+		copy(piece, s.pieces[idx])
+
+		pieces = append(pieces, &kodr_internals.CodedPiece{
+			Vector:  s.systematicCodingVector(idx),
+			Piece:   piece,
+			Field:   s.field.ID(),
+			Version: s.version,
+		})
+	}
+	return pieces, nil
+}
+
+// TargetedCodedPiece - Returns a coded piece, which randomly combines only
+// requested original pieces, with nonzero coefficients, so that any one
+// of such pieces can repair any one missing piece, without knowing which
+// of those receiver is going to lose again
+//
+// Requested pieces are listed using bitmap, as returned by decoder's `Missing`.
+// If none is requested, returns error, as nothing needs to be repaired
+func (s *SystematicRLNCEncoder) TargetedCodedPiece(bitmap []byte) (*kodr_internals.CodedPiece, error) {
+	indices, err := MissingIndices(bitmap, s.PieceCount())
+	if err != nil {
+		return nil, err
+	}
+	if len(indices) == 0 {
+		return nil, kodr.ErrAllUsefulPiecesReceived
+	}
+
+	vector := make(kodr_internals.CodingVector, s.PieceCount()*s.field.SymbolSize())
+	piece := make(kodr_internals.Piece, s.PieceSize())
+	for _, idx := range indices {
+		c := s.field.Random()
+		for c == 0 {
+			c = s.field.Random()
+		}
+
+		s.field.SetSymbol(vector, idx, c)
+		s.field.MulAddSlice(piece, s.pieces[idx], c)
+	}
+
+	return &kodr_internals.CodedPiece{
+		Vector:  vector,
+		Piece:   piece,
+		Field:   s.field.ID(),
+		Version: s.version,
+	}, nil
+}
@@ -0,0 +1,148 @@
+package systematic_test
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/systematic"
+)
+
+// Hybrid ARQ loop, where lossy systematic phase is followed by rounds of
+// negative acknowledgements, each answered with targeted coded pieces,
+// which are lossy too, until receiver decodes everything
+func TestSystematicRLNCNack(t *testing.T) {
+	var (
+		pieceCount  uint = 100
+		pieceLength uint = 256
+		pieces           = generatePieces(pieceCount, pieceLength)
+		enc              = systematic.NewSystematicRLNCEncoder(pieces)
+		dec              = systematic.NewSystematicRLNCDecoder(pieceCount)
+	)
+
+	lost := make(map[uint]bool)
+	for i := range pieceCount {
+		c_piece := enc.CodedPiece()
+		if rand.Intn(10) < 3 {
+			lost[i] = true
+			continue
+		}
+		if err := dec.AddPiece(c_piece); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	bitmap := dec.Missing()
+	missing, err := systematic.MissingIndices(bitmap, pieceCount)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(missing) != len(lost) {
+		t.Fatalf("expected %d missing pieces, found %d\n", len(lost), len(missing))
+	}
+	for _, idx := range missing {
+		if !lost[idx] {
+			t.Fatalf("piece %d is reported missing, though received\n", idx)
+		}
+	}
+
+	for !dec.IsDecoded() {
+		count := len(missing)
+		for range count {
+			if dec.IsDecoded() {
+				break
+			}
+
+			c_piece, err := enc.TargetedCodedPiece(bitmap)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if rand.Intn(10) < 3 {
+				continue
+			}
+			if err := dec.AddPiece(c_piece); err != nil {
+				t.Fatal(err.Error())
+			}
+		}
+
+		bitmap = dec.Missing()
+		if missing, err = systematic.MissingIndices(bitmap, pieceCount); err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(missing) > count {
+			t.Fatalf("missing piece count mustn't grow, from %d to %d\n", count, len(missing))
+		}
+	}
+
+	if len(missing) != 0 {
+		t.Fatalf("expected nothing missing, found %d\n", len(missing))
+	}
+	if dec.Rank() != pieceCount {
+		t.Fatalf("targeted pieces must all be innovative, found rank %d\n", dec.Rank())
+	}
+
+	d_pieces, err := dec.GetPieces()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	for i := range pieceCount {
+		if !bytes.Equal(pieces[i], d_pieces[i]) {
+			t.Fatal("decoded data doesn't match !")
+		}
+	}
+
+	if _, err := enc.TargetedCodedPiece(bitmap); !errors.Is(err, kodr.ErrAllUsefulPiecesReceived) {
+		t.Fatalf("expected: %s\n", kodr.ErrAllUsefulPiecesReceived)
+	}
+}
+
+func TestSystematicRLNCUncodedPieces(t *testing.T) {
+	var (
+		pieceCount  uint = 20
+		pieceLength uint = 64
+		pieces           = generatePieces(pieceCount, pieceLength)
+		enc              = systematic.NewSystematicRLNCEncoder(pieces)
+		dec              = systematic.NewSystematicRLNCDecoder(pieceCount)
+	)
+
+	// only odd indexed pieces make it through
+	for i := range pieceCount {
+		c_piece := enc.CodedPiece()
+		if i%2 == 0 {
+			continue
+		}
+		if err := dec.AddPiece(c_piece); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	bitmap := dec.Missing()
+	if !bytes.Equal(bitmap, []byte{0b01010101, 0b01010101, 0b0101}) {
+		t.Fatalf("unexpected bitmap %08b\n", bitmap)
+	}
+
+	c_pieces, err := enc.UncodedPieces(bitmap)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(c_pieces) != int(pieceCount/2) {
+		t.Fatalf("expected %d uncoded pieces, found %d\n", pieceCount/2, len(c_pieces))
+	}
+	for _, c_piece := range c_pieces {
+		if err := dec.AddPiece(c_piece); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+	if !dec.IsDecoded() {
+		t.Fatal("expected to be fully decoded !")
+	}
+
+	if _, err := enc.UncodedPieces(bitmap[:2]); !errors.Is(err, kodr.ErrMalformedBitmap) {
+		t.Fatalf("expected: %s\n", kodr.ErrMalformedBitmap)
+	}
+	if _, err := enc.TargetedCodedPiece([]byte{0, 0, 0b10000}); !errors.Is(err, kodr.ErrMalformedBitmap) {
+		t.Fatalf("expected: %s\n", kodr.ErrMalformedBitmap)
+	}
+}
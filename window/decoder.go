@@ -0,0 +1,180 @@
+package window
+
+import (
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/kodr_internals/matrix"
+)
+
+// Decoder for pieces coded by expanding window encoder, which keeps
+// track of how many leading original pieces are already decoded i.e.
+// leading rows of RREF coefficient matrix being unit vectors, so that
+// each window is reported decodable, as soon as it's so, even if whole
+// generation isn't
+type ExpandingWindowDecoder struct {
+	windows           []uint
+	state             *matrix.DecoderState
+	useful, received  uint
+	prefix, decodable uint
+	onDecodable       func(window uint)
+}
+
+// IsDecoded - Use it for checking whether more piece
+// collection is required or not
+func (d *ExpandingWindowDecoder) IsDecoded() bool {
+	return d.useful >= d.PieceCount()
+}
+
+// Total #-of pieces being coded together i.e. size of last window
+func (d *ExpandingWindowDecoder) PieceCount() uint {
+	return d.windows[len(d.windows)-1]
+}
+
+// Required - How many more linearly independent pieces
+// are required for successfully decoding all pieces ?
+func (d *ExpandingWindowDecoder) Required() uint {
+	return d.PieceCount() - d.useful
+}
+
+// Received - How many coded pieces are received so far, including
+// linearly dependent ones
+func (d *ExpandingWindowDecoder) Received() uint {
+	return d.received
+}
+
+// Rank - How many linearly independent pieces are received so far
+func (d *ExpandingWindowDecoder) Rank() uint {
+	return d.useful
+}
+
+// #-of leading windows, which are already decodable
+func (d *ExpandingWindowDecoder) DecodableWindows() uint {
+	return d.decodable
+}
+
+// Whether all pieces of given window are already decoded
+func (d *ExpandingWindowDecoder) IsWindowDecodable(w uint) bool {
+	return w < d.decodable
+}
+
+// Registers callback, which is invoked ( in order ) with index of each
+// window, which becomes decodable, while adding coded piece
+func (d *ExpandingWindowDecoder) OnWindowDecodable(fn func(window uint)) {
+	d.onDecodable = fn
+}
+
+// Extends count of leading decoded pieces, as far as leading rows of
+// RREF coefficient matrix are unit vectors, then reports windows,
+// which became decodable
+func (d *ExpandingWindowDecoder) advance() {
+	coeffs := d.state.CoefficientMatrix()
+
+OUT:
+	for d.prefix < coeffs.Rows() {
+		for i, c := range coeffs[d.prefix] {
+			if (uint(i) == d.prefix) != (c != 0) {
+				break OUT
+			}
+		}
+		d.prefix++
+	}
+
+	for d.decodable < uint(len(d.windows)) && d.windows[d.decodable] <= d.prefix {
+		d.decodable++
+		if d.onDecodable != nil {
+			d.onDecodable(d.decodable - 1)
+		}
+	}
+}
+
+// AddPiece - Adds a new received coded piece, rref-s augmented matrix
+// & reports windows, which became decodable
+//
+// Note: Coded piece is copied, caller's slices are never modified
+func (d *ExpandingWindowDecoder) AddPiece(piece *kodr_internals.CodedPiece) error {
+	if d.IsDecoded() {
+		return kodr.ErrAllUsefulPiecesReceived
+	}
+	if uint(len(piece.Vector)) != d.PieceCount() {
+		return kodr.ErrCodingVectorLengthMismatch
+	}
+	if piece.Field != 0 && piece.Field != d.state.Field().ID() {
+		return kodr.ErrFieldMismatch
+	}
+	if l := d.state.PieceLength(); l != 0 && uint(len(piece.Piece)) != l {
+		return kodr.ErrCodedDataLengthMismatch
+	}
+
+	vector := make(kodr_internals.CodingVector, len(piece.Vector))
+	copy(vector, piece.Vector)
+	c_piece := make(kodr_internals.Piece, len(piece.Piece))
+	copy(c_piece, piece.Piece)
+
+	d.state.AddPiece(&kodr_internals.CodedPiece{Vector: vector, Piece: c_piece})
+	d.received++
+	d.state.Rref()
+	d.useful = d.state.Rank()
+	d.advance()
+
+	return nil
+}
+
+// GetPiece - Get a decoded piece by index, which succeeds only
+// if it's part of a decodable window
+func (d *ExpandingWindowDecoder) GetPiece(i uint) (kodr_internals.Piece, error) {
+	if i >= d.PieceCount() {
+		return nil, kodr.ErrPieceOutOfBound
+	}
+	if i >= d.prefix {
+		return nil, kodr.ErrPieceNotDecodedYet
+	}
+
+	coded := d.state.CodedPieceMatrix()
+	buf := make(kodr_internals.Piece, len(coded[i]))
+	copy(buf, coded[i])
+	return buf, nil
+}
+
+// GetWindow - Get all decoded pieces of given window, given
+// it's already decodable
+func (d *ExpandingWindowDecoder) GetWindow(w uint) ([]kodr_internals.Piece, error) {
+	if w >= uint(len(d.windows)) {
+		return nil, kodr.ErrWindowOutOfBound
+	}
+	if !d.IsWindowDecodable(w) {
+		return nil, kodr.ErrMoreUsefulPiecesRequired
+	}
+
+	pieces := make([]kodr_internals.Piece, 0, d.windows[w])
+	for i := range d.windows[w] {
+		piece, err := d.GetPiece(i)
+		if err != nil {
+			return nil, err
+		}
+		pieces = append(pieces, piece)
+	}
+	return pieces, nil
+}
+
+// GetPieces - Get a list of all decoded pieces, given full
+// decoding has happened
+func (d *ExpandingWindowDecoder) GetPieces() ([]kodr_internals.Piece, error) {
+	return d.GetWindow(uint(len(d.windows)) - 1)
+}
+
+// Decoder for pieces coded by expanding window encoder, with same
+// nested window sizes i.e. strictly increasing, last one being
+// #-of pieces coded together
+func NewExpandingWindowDecoder(windows []uint) (*ExpandingWindowDecoder, error) {
+	if len(windows) == 0 || windows[0] == 0 {
+		return nil, kodr.ErrBadWindows
+	}
+	for i := 1; i < len(windows); i++ {
+		if windows[i] <= windows[i-1] {
+			return nil, kodr.ErrBadWindows
+		}
+	}
+
+	state := matrix.NewDecoderStateWithPieceCount(windows[len(windows)-1])
+	return &ExpandingWindowDecoder{windows: windows, state: state}, nil
+}
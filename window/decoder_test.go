@@ -0,0 +1,146 @@
+package window_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+	"github.com/itzmeanjan/kodr/window"
+)
+
+// Generates N-many pieces each of M-bytes length, to be used
+// for testing purposes
+func generatePieces(pieceCount uint, pieceLength uint) []kodr_internals.Piece {
+	pieces := make([]kodr_internals.Piece, 0, pieceCount)
+	for range pieceCount {
+		piece := make(kodr_internals.Piece, pieceLength)
+		rand.Read(piece)
+		pieces = append(pieces, piece)
+	}
+	return pieces
+}
+
+func TestExpandingWindowParameters(t *testing.T) {
+	pieces := generatePieces(32, 64)
+
+	for _, windows := range [][]uint{{}, {0, 32}, {16, 8, 32}, {16, 16, 32}, {8, 16}} {
+		if _, err := window.NewExpandingWindowEncoder(pieces, windows, make([]float64, len(windows))); !errors.Is(err, kodr.ErrBadWindows) {
+			t.Fatalf("windows %v, expected: %s\n", windows, kodr.ErrBadWindows)
+		}
+	}
+
+	for _, probabilities := range [][]float64{{1}, {0, 0}, {-1, 2}} {
+		if _, err := window.NewExpandingWindowEncoder(pieces, []uint{8, 32}, probabilities); !errors.Is(err, kodr.ErrBadWindowProbabilities) {
+			t.Fatalf("probabilities %v, expected: %s\n", probabilities, kodr.ErrBadWindowProbabilities)
+		}
+	}
+
+	enc, err := window.NewExpandingWindowEncoder(pieces, []uint{8, 32}, []float64{1, 0})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := enc.CodedPieceForWindow(2); !errors.Is(err, kodr.ErrWindowOutOfBound) {
+		t.Fatalf("expected: %s\n", kodr.ErrWindowOutOfBound)
+	}
+
+	// only first window is ever coded over
+	for range 16 {
+		c_piece := enc.CodedPiece()
+		for _, c := range c_piece.Vector[8:] {
+			if c != 0 {
+				t.Fatal("coded piece spans beyond chosen window")
+			}
+		}
+	}
+}
+
+func TestExpandingWindowDecoder(t *testing.T) {
+	var (
+		windows       = []uint{16, 48, 128}
+		probabilities = []float64{0.4, 0.3, 0.3}
+		pieces        = generatePieces(windows[2], 256)
+		decodedAt     = make([]uint, 0, len(windows))
+	)
+
+	enc, err := window.NewExpandingWindowEncoder(pieces, windows, probabilities)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	dec, err := window.NewExpandingWindowDecoder(windows)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	dec.OnWindowDecodable(func(w uint) {
+		if w != uint(len(decodedAt)) {
+			t.Fatalf("window %d reported decodable, before window %d\n", w, len(decodedAt))
+		}
+		decodedAt = append(decodedAt, dec.Received())
+
+		d_pieces, err := dec.GetWindow(w)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		for i := range d_pieces {
+			if !bytes.Equal(pieces[i], d_pieces[i]) {
+				t.Fatal("decoded data doesn't match !")
+			}
+		}
+	})
+
+	for !dec.IsDecoded() {
+		if _, err := dec.GetWindow(dec.DecodableWindows()); !errors.Is(err, kodr.ErrMoreUsefulPiecesRequired) {
+			t.Fatalf("expected: %s\n", kodr.ErrMoreUsefulPiecesRequired)
+		}
+		if err := dec.AddPiece(enc.CodedPiece()); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	if len(decodedAt) != len(windows) {
+		t.Fatalf("%d windows reported decodable, expected %d\n", len(decodedAt), len(windows))
+	}
+	// base window must be decodable long before whole generation
+	if decodedAt[0] >= windows[2] {
+		t.Fatalf("base window decoded after %d pieces, whole generation needs %d\n", decodedAt[0], windows[2])
+	}
+
+	if _, err := dec.GetPiece(windows[2]); !errors.Is(err, kodr.ErrPieceOutOfBound) {
+		t.Fatalf("expected: %s\n", kodr.ErrPieceOutOfBound)
+	}
+	if err := dec.AddPiece(enc.CodedPiece()); !errors.Is(err, kodr.ErrAllUsefulPiecesReceived) {
+		t.Fatalf("expected: %s\n", kodr.ErrAllUsefulPiecesReceived)
+	}
+}
+
+func TestExpandingWindowDecoderPieceLengthMismatch(t *testing.T) {
+	var (
+		windows     = []uint{8, 32}
+		pieceLength = uint(64)
+	)
+
+	enc, err := window.NewExpandingWindowEncoder(generatePieces(windows[1], pieceLength), windows, []float64{0.5, 0.5})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	dec, err := window.NewExpandingWindowDecoder(windows)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if err := dec.AddPiece(enc.CodedPiece()); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	c_piece := enc.CodedPiece()
+	c_piece.Piece = c_piece.Piece[:pieceLength-1]
+	if err := dec.AddPiece(c_piece); !errors.Is(err, kodr.ErrCodedDataLengthMismatch) {
+		t.Fatalf("expected: %s\n", kodr.ErrCodedDataLengthMismatch)
+	}
+	if dec.Received() != 1 {
+		t.Fatalf("expected 1 piece received, found %d\n", dec.Received())
+	}
+}
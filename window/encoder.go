@@ -0,0 +1,141 @@
+// Package window implements expanding window codes, for unequal error
+// protection, where original pieces are grouped into nested windows i.e.
+// window 1 ⊂ window 2 ⊂ … ⊂ all pieces, each being a prefix of pieces.
+// Each coded piece is full RLNC coded over one window, chosen as per
+// configured probabilities, so that most important pieces ( i.e. base
+// layer of video ) are decodable much before whole generation.
+package window
+
+import (
+	"math/rand"
+
+	"github.com/itzmeanjan/kodr"
+	"github.com/itzmeanjan/kodr/full"
+	"github.com/itzmeanjan/kodr/kodr_internals"
+)
+
+type ExpandingWindowEncoder struct {
+	windows []uint
+	// Full RLNC encoder over pieces of each window
+	encoders []*full.FullRLNCEncoder
+	// Cumulative probability of choosing window
+	cdf   []float64
+	extra uint
+}
+
+// Total #-of pieces being coded together i.e. size of last window
+func (e *ExpandingWindowEncoder) PieceCount() uint {
+	return e.windows[len(e.windows)-1]
+}
+
+// Pieces which are coded together are all of same size
+func (e *ExpandingWindowEncoder) PieceSize() uint {
+	return e.encoders[0].PieceSize()
+}
+
+// If N-many original pieces are coded together
+// what could be length of one such coded piece
+// obtained by invoking `CodedPiece` ?
+func (e *ExpandingWindowEncoder) CodedPieceLen() uint {
+	return e.PieceCount() + e.PieceSize()
+}
+
+// How many extra padding bytes added at end of
+// original data slice so that splitted pieces are
+// all of same size ?
+func (e *ExpandingWindowEncoder) Padding() uint {
+	return e.extra
+}
+
+// #-of nested windows
+func (e *ExpandingWindowEncoder) WindowCount() uint {
+	return uint(len(e.windows))
+}
+
+// Returns a coded piece, over window chosen as per configured probabilities
+func (e *ExpandingWindowEncoder) CodedPiece() *kodr_internals.CodedPiece {
+	r := rand.Float64()
+	for w, c := range e.cdf {
+		if r < c {
+			c_piece, _ := e.CodedPieceForWindow(uint(w))
+			return c_piece
+		}
+	}
+
+	c_piece, _ := e.CodedPieceForWindow(e.WindowCount() - 1)
+	return c_piece
+}
+
+// Returns a coded piece, which is full RLNC coded over pieces of given
+// window only, while coding vector is still N coefficients long, with
+// ones beyond window set to zero
+func (e *ExpandingWindowEncoder) CodedPieceForWindow(w uint) (*kodr_internals.CodedPiece, error) {
+	if w >= e.WindowCount() {
+		return nil, kodr.ErrWindowOutOfBound
+	}
+
+	c_piece := e.encoders[w].CodedPiece()
+	vector := make(kodr_internals.CodingVector, e.PieceCount())
+	copy(vector, c_piece.Vector)
+	c_piece.Vector = vector
+
+	return c_piece, nil
+}
+
+// Provide with original pieces, sizes of nested windows ( strictly
+// increasing, last one must be equal to #-of pieces ) & relative
+// probability of coding over each window, which need not sum to 1
+func NewExpandingWindowEncoder(pieces []kodr_internals.Piece, windows []uint, probabilities []float64) (*ExpandingWindowEncoder, error) {
+	if len(windows) == 0 || windows[0] == 0 || windows[len(windows)-1] != uint(len(pieces)) {
+		return nil, kodr.ErrBadWindows
+	}
+	for i := 1; i < len(windows); i++ {
+		if windows[i] <= windows[i-1] {
+			return nil, kodr.ErrBadWindows
+		}
+	}
+
+	if len(probabilities) != len(windows) {
+		return nil, kodr.ErrBadWindowProbabilities
+	}
+	total := 0.
+	for _, p := range probabilities {
+		if !(p >= 0) {
+			return nil, kodr.ErrBadWindowProbabilities
+		}
+		total += p
+	}
+	if !(total > 0) {
+		return nil, kodr.ErrBadWindowProbabilities
+	}
+
+	cdf := make([]float64, len(probabilities))
+	encoders := make([]*full.FullRLNCEncoder, len(windows))
+	acc := 0.
+	for w, p := range probabilities {
+		acc += p
+		cdf[w] = acc / total
+		encoders[w] = full.NewFullRLNCEncoder(pieces[:windows[w]])
+	}
+
+	return &ExpandingWindowEncoder{windows: windows, encoders: encoders, cdf: cdf}, nil
+}
+
+// If you know #-of pieces you want to code together, invoking
+// this function splits whole data chunk into N-pieces, with padding
+// bytes appended at end of last piece, if required & prepares
+// expanding window encoder
+func NewExpandingWindowEncoderWithPieceCount(data []byte, pieceCount uint, windows []uint, probabilities []float64) (*ExpandingWindowEncoder, error) {
+	pieces, padding, err := kodr_internals.OriginalPiecesFromDataAndPieceCount(data, pieceCount)
+	if err != nil {
+		return nil, err
+	}
+
+	enc, err := NewExpandingWindowEncoder(pieces, windows, probabilities)
+	if err != nil {
+		return nil, err
+	}
+
+	enc.extra = padding
+	return enc, nil
+}